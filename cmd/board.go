@@ -0,0 +1,181 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/tui"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// boardCmd represents the board command
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Open the kanban board of a workspace",
+	Long: `Open a full-screen kanban board of a workspace, with its categories as columns.
+
+Move between columns and cards with the arrow keys (or h, j, k and l), move the selected card to the
+previous or next column with < and >, and show its details and comments with enter.
+The board is refreshed periodically, and with r.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tui.IsInteractive() {
+			log.Fatal("the board can only be shown in a terminal")
+		}
+
+		client := newClient()
+
+		workspaceId, err := utils.ResolveWorkspaceId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if workspaceId == 0 {
+			log.Fatal("either --workspace or --workspace-id is required, or set a default with `zube config set default_workspace`")
+		}
+
+		workspace, ok := lo.Find(client.FetchWorkspaces(&zube.Query{}), func(w models.Workspace) bool { return w.Id == workspaceId })
+		if !ok {
+			log.Fatalf("workspace %d not found", workspaceId)
+		}
+
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+
+		board := newWorkspaceBoard(client, workspace)
+		board.Refresh = refresh
+
+		if err := board.Run(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// Creates a board of the categories of a workspace, and the cards in them
+func newWorkspaceBoard(client *zube.Client, workspace models.Workspace) *tui.Board {
+	// The client is not safe for concurrent use, and the board loads everything in the background
+	var mu sync.Mutex
+
+	return &tui.Board{
+		Title: workspace.Name,
+		Load: func() ([]tui.BoardColumn, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			categories, err := api.FetchCategories(client, workspace.Id)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(categories, func(i, j int) bool { return categories[i].Position < categories[j].Position })
+
+			cards, err := api.FetchWorkspaceCards(client, workspace.Id)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(cards, func(i, j int) bool { return cards[i].Position < cards[j].Position })
+
+			columns := make([]tui.BoardColumn, len(categories))
+			for i, category := range categories {
+				columns[i].Name = category.Name
+
+				for _, card := range cards {
+					if strings.EqualFold(card.CategoryName, category.Name) {
+						columns[i].Cards = append(columns[i].Cards, boardCard(card.Card))
+					}
+				}
+			}
+
+			return columns, nil
+		},
+		Move: func(card tui.BoardCard, column tui.BoardColumn) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			position := lo.IndexOf(lo.Map(column.Cards, func(c tui.BoardCard, _ int) string { return c.Id }), card.Id)
+			destination := api.Destination{Type: "category", Name: column.Name, WorkspaceId: workspace.Id, Position: position}
+
+			_, err := api.MoveCard(client, card.Value.(models.Card).Id, destination)
+			return err
+		},
+		Details: func(card tui.BoardCard, width int) string {
+			mu.Lock()
+			defer mu.Unlock()
+
+			details := card.Value.(models.Card)
+			account, project, err := fetchCardProject(client, &details)
+			if err != nil {
+				return err.Error()
+			}
+			comments := client.FetchCardComments(details.Id)
+
+			var text bytes.Buffer
+			utils.FprintCard(&text, width, &account, &project, &details)
+			fmt.Fprintln(&text)
+			utils.FprintComments(&text, width, &comments)
+			return text.String()
+		},
+	}
+}
+
+// A card tile shows its number and title, its priority and labels, and its assignees
+func boardCard(card models.Card) tui.BoardCard {
+	tile := tui.BoardCard{
+		Id:    strconv.Itoa(card.Id),
+		Title: fmt.Sprintf("%s %s", BrightGreen(fmt.Sprintf("#%d", card.Number)), card.Title),
+		Value: card,
+	}
+
+	var tags []string
+	if priority := card.Priority.OrElse(0); priority != 0 {
+		tags = append(tags, Yellow(fmt.Sprintf("P%d", priority)).String())
+	}
+	for _, label := range card.Labels {
+		tags = append(tags, utils.FormatLabel(label))
+	}
+	if len(tags) > 0 {
+		tile.Meta = append(tile.Meta, strings.Join(tags, " "))
+	}
+
+	var assignees []string
+	for _, assignee := range card.Assignees {
+		assignees = append(assignees, "@"+assignee.Username)
+	}
+	if len(assignees) > 0 {
+		tile.Meta = append(tile.Meta, Gray(14, strings.Join(assignees, " ")).String())
+	}
+
+	return tile
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+
+	boardCmd.Flags().String("workspace", "", "Name of the workspace")
+	boardCmd.Flags().Int("workspace-id", 0, "ID of the workspace")
+	boardCmd.Flags().Duration("refresh", 30*time.Second, "How often the board is refreshed, 0 to never refresh")
+}
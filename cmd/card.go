@@ -4,15 +4,34 @@ Copyright © 2022 Daniils Petrovs <daniils@platogo.com>
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/tui"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 // cardCmd represents the card command
 var cardCmd = &cobra.Command{
 	Use:   "card",
 	Short: "Manage cards",
+	Long: `Manage cards.
+
+Commands that take a card accept its number (123 or #123), its number within a project by name or slug
+(Backend/123), or its Zube URL. When a number exists in several projects, you are asked which card you meant.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("try to use `card ls` to list cards")
 	},
@@ -21,3 +40,303 @@ var cardCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(cardCmd)
 }
+
+// Fetches a single card by a reference like `123`, `#123`, `Project/123` or its URL. When a bare number
+// matches cards in several projects, the user picks one of them.
+func fetchCardByRef(client *zube.Client, value string) (models.Card, error) {
+	ref, err := utils.ParseCardRef(value)
+	if err != nil {
+		return models.Card{}, err
+	}
+
+	projects := client.FetchProjects(&zube.Query{})
+	where := map[string]any{"number": ref.Number}
+
+	if ref.Project != "" {
+		project, err := findRefProject(client, ref, projects)
+		if err != nil {
+			return models.Card{}, err
+		}
+		where["project_id"] = project.Id
+	}
+
+	cards := client.FetchCards(&zube.Query{Filter: zube.Filter{Where: where}})
+
+	switch len(cards) {
+	case 0:
+		return models.Card{}, fmt.Errorf("card %s not found", ref)
+	case 1:
+		return cards[0], nil
+	}
+
+	return chooseAmbiguousCard(ref, cards, projects)
+}
+
+// Finds the project of a card reference by its whole name or slug, within the account of the reference if it has one
+func findRefProject(client *zube.Client, ref utils.CardRef, projects []models.Project) (models.Project, error) {
+	if ref.Account != "" {
+		accounts := client.FetchAccounts(&zube.Query{})
+		account, ok := lo.Find(accounts, func(a models.Account) bool { return strings.EqualFold(a.Slug, ref.Account) })
+		if !ok {
+			var err error
+			if account, err = utils.FindByNameMatching(utils.ExactMatch, "account", ref.Account, accounts, func(a models.Account) string { return a.Name }); err != nil {
+				return models.Project{}, err
+			}
+		}
+		projects = lo.Filter(projects, func(p models.Project, _ int) bool { return p.AccountId == account.Id })
+	}
+
+	if project, ok := lo.Find(projects, func(p models.Project) bool { return strings.EqualFold(p.Slug, ref.Project) }); ok {
+		return project, nil
+	}
+
+	return utils.FindByNameMatching(utils.ExactMatch, "project", ref.Project, projects, func(p models.Project) string { return p.Name })
+}
+
+// Asks the user which of the cards with the same number in different projects they meant
+func chooseAmbiguousCard(ref utils.CardRef, cards []models.Card, projects []models.Project) (models.Card, error) {
+	options := make([]string, len(cards))
+	for i, card := range cards {
+		projectName := strconv.Itoa(card.ProjectId)
+		if project, ok := lo.Find(projects, func(p models.Project) bool { return p.Id == card.ProjectId }); ok {
+			projectName = project.Name
+		}
+		options[i] = fmt.Sprintf("%s/%d %s", projectName, card.Number, card.Title)
+	}
+
+	if !tui.IsInteractive() {
+		return models.Card{}, fmt.Errorf("card %s is ambiguous, it matches: %s", ref, strings.Join(options, ", "))
+	}
+
+	var index int
+	prompt := &survey.Select{
+		Message:  fmt.Sprintf("Card %s exists in several projects:", ref),
+		Options:  options,
+		PageSize: 10,
+	}
+	if err := survey.AskOne(prompt, &index); err != nil {
+		return models.Card{}, err
+	}
+
+	return cards[index], nil
+}
+
+// Returns the card referenced by the first argument or, without one, lets the user pick a card
+func cardFromArgs(client *zube.Client, args []string) (models.Card, error) {
+	if len(args) > 0 {
+		return fetchCardByRef(client, args[0])
+	}
+
+	if !tui.IsInteractive() {
+		return models.Card{}, errors.New("a card is required when not running in a terminal")
+	}
+
+	return pickCard(client)
+}
+
+// Fetches the account and project of a card, which make up its URL
+func fetchCardProject(client *zube.Client, card *models.Card) (models.Account, models.Project, error) {
+	projectQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": card.ProjectId}}}
+	projects := client.FetchProjects(&projectQueryById)
+	if len(projects) == 0 {
+		return models.Account{}, models.Project{}, fmt.Errorf("project %d of card #%d not found", card.ProjectId, card.Number)
+	}
+
+	account, err := fetchProjectAccount(client, &projects[0])
+	return account, projects[0], err
+}
+
+// Fetches the account a project belongs to
+func fetchProjectAccount(client *zube.Client, project *models.Project) (models.Account, error) {
+	accountQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": project.AccountId}}}
+	accounts := client.FetchAccounts(&accountQueryById)
+	if len(accounts) == 0 {
+		return models.Account{}, fmt.Errorf("account %d of project %s not found", project.AccountId, project.Name)
+	}
+
+	return accounts[0], nil
+}
+
+// Adds the card filter flags understood by `utils.NewQueryFromFlags` and `utils.ResolveQueryNames`
+func addCardQueryFlags(flags *pflag.FlagSet) {
+	flags.Int("id", 0, "Filter by card internal ID")
+	flags.String("category", "", "Filter by category name")
+	flags.Int("epic-id", 0, "Filter by epic ID")
+	flags.Int("number", 0, "Filter by card number")
+	flags.Int("priority", -1, "Filter by priority")
+	flags.Int("project-id", 0, "Filter by project ID")
+	flags.Int("sprint-id", 0, "Filter by sprint ID")
+	flags.Int("workspace-id", 0, "Filter by workspace ID")
+	flags.String("assignee-id", "", "Filter by assignee")
+	flags.String("state", "", "Filter by card state")
+	flags.StringSlice("status", nil, "Filter by card status, several separated by commas")
+
+	flags.String("project", "", "Filter by project name")
+	flags.String("workspace", "", "Filter by workspace name")
+	flags.String("sprint", "", "Filter by sprint title, or @current-sprint (requires a workspace)")
+	flags.String("epic", "", "Filter by epic title (requires a project)")
+	flags.String("assignee", "", "Filter by assignee name, or @me (other names require a project)")
+	flags.String("label", "", "Filter by label name (requires a project)")
+	flags.StringSlice("not-label", nil, "Exclude cards with any of the given label names")
+	flags.String("created-since", "", "Filter by cards created since a date, e.g. 2026-01-01, 7d, 2w, yesterday or monday")
+	flags.String("created-before", "", "Filter by cards created before a date")
+	flags.String("updated-since", "", "Filter by cards updated since a date")
+	flags.String("updated-before", "", "Filter by cards updated before a date")
+	flags.String("closed-since", "", "Filter by cards closed since a date")
+	flags.String("closed-before", "", "Filter by cards closed before a date")
+	flags.StringP("query", "q", "", "Filter with a ZQL query, e.g. 'status:in_progress assignee:@me -label:wontfix'")
+}
+
+// Adds the --columns flag of card tables
+func addCardColumnsFlag(flags *pflag.FlagSet) {
+	flags.StringSlice("columns", nil, "Card table columns to show, any of: number, title, status, assignees, labels, priority, epic")
+}
+
+// Binds the --columns flag of a card listing to its setting. Only card listings have it, since the columns
+// differ for every kind of table.
+func bindCardColumnsFlag(cmd *cobra.Command) {
+	if flag := cmd.Flags().Lookup("columns"); flag != nil {
+		viper.BindPFlag("columns", flag)
+	}
+}
+
+// Builds a card query from the filter flags. Returns a predicate for the filters that must be applied
+// client-side, or nil. With `search` set, bare words of a ZQL query become the search text. Commands that
+// change the cards pass `utils.ExactMatch`, so they never act on a partially matched name.
+func cardQueryFromFlags(client *zube.Client, flags *pflag.FlagSet, search bool, match utils.NameMatch) (zube.Query, utils.CardPredicate, error) {
+	query := utils.NewQueryFromFlags(flags)
+
+	if err := utils.ResolveQueryNames(client, flags, &query, match); err != nil {
+		return query, nil, err
+	}
+
+	filter, err := utils.CardFilterFromFlags(flags, time.Now())
+	if err != nil {
+		return query, nil, err
+	}
+
+	// Client-side filters may need any card attribute, not just the selected table columns
+	if filter != nil {
+		query.Filter.Select = nil
+	}
+
+	zqlQuery, _ := flags.GetString("query")
+	predicate, err := utils.ApplyZQL(client, zqlQuery, &query, search, match)
+
+	return query, utils.AllOf(filter, predicate), err
+}
+
+// Reads a Markdown body from the --body or --body-file flags, or else from an editor prefilled with `current`
+func bodyFromFlags(flags *pflag.FlagSet, message, current string) (string, error) {
+	if flags.Changed("body") {
+		return flags.GetString("body")
+	}
+
+	if bodyFile, _ := flags.GetString("body-file"); bodyFile != "" {
+		return utils.ReadFileOrStdin(bodyFile)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New("either --body or --body-file is required when not running in a terminal")
+	}
+
+	var body string
+	prompt := &survey.Editor{
+		Message:       message,
+		FileName:      "*.md",
+		Default:       current,
+		AppendDefault: true,
+		HideDefault:   true,
+	}
+	err := survey.AskOne(prompt, &body)
+
+	return body, err
+}
+
+// Adds the --limit, --page, --all and --parallel flags for paginated card listings
+func addPaginationFlags(flags *pflag.FlagSet) {
+	flags.Int("limit", 0, "Maximum number of cards to list, fetching as many pages as needed")
+	flags.Int("page", 0, "Only list the given page of results")
+	flags.Bool("all", false, "List every page of results")
+	flags.Int("parallel", 4, "Number of pages fetched concurrently with --all")
+	flags.Int("per-page", 0, "Number of cards fetched per page (default is the Zube API default)")
+}
+
+// Returns the pagination options given as flags, and whether any were given at all
+func pageOptionsFromFlags(flags *pflag.FlagSet) (api.PageOptions, bool) {
+	var opts api.PageOptions
+
+	opts.Limit, _ = flags.GetInt("limit")
+	opts.Page, _ = flags.GetInt("page")
+	opts.All, _ = flags.GetBool("all")
+	opts.Parallel, _ = flags.GetInt("parallel")
+	opts.PerPage, _ = flags.GetInt("per-page")
+
+	if opts.All && opts.PerPage == 0 {
+		opts.PerPage = 100
+	}
+
+	return opts, opts.Limit > 0 || opts.Page > 0 || opts.All
+}
+
+// Fetches the pages of cards matching the query from `path`, and prints them as they arrive.
+// The client-side predicate is applied to each page.
+func streamCards(client *zube.Client, path string, query *zube.Query, predicate utils.CardPredicate, opts api.PageOptions) error {
+	stream := utils.NewCardStream()
+
+	err := eachCardPage(client, path, query, predicate, opts, func(cards []models.Card) error {
+		utils.LoadEpicTitles(client, cards)
+		stream.Write(cards)
+		return nil
+	})
+
+	stream.Flush()
+	return err
+}
+
+// Walks the pages of cards matching the query from `path`, and calls `each` with the cards of every page
+// that match the client-side predicate. A predicate can only check the cards that were fetched, so with one,
+// pages are fetched until --limit cards match, every page with --all, or else until as many cards match as
+// a single page holds. Only a single --page is filtered as is.
+func eachCardPage(client *zube.Client, path string, query *zube.Query, predicate utils.CardPredicate, opts api.PageOptions, each func(cards []models.Card) error) error {
+	if predicate == nil {
+		return api.EachPage(client, path, query, opts, each)
+	}
+
+	if opts.Page > 0 && !opts.All && opts.Limit == 0 {
+		fmt.Fprintf(os.Stderr, "Only the cards of page %d are filtered, results may be incomplete\n", opts.Page)
+		return api.EachPage(client, path, query, opts, func(cards []models.Card) error {
+			return each(utils.FilterCards(cards, predicate))
+		})
+	}
+
+	limit := opts.Limit
+	// The size of the first page is the limit, so the listing is as long as without client-side filters
+	onePage := !opts.All && limit == 0
+	opts.Limit, opts.All = 0, true
+	if opts.PerPage == 0 && !onePage {
+		opts.PerPage = 100
+	}
+	// Pages are fetched one by one up to the limit, so no more pages are fetched than needed
+	if limit > 0 || onePage {
+		opts.Parallel = 1
+	}
+
+	remaining := limit
+	return api.EachPage(client, path, query, opts, func(cards []models.Card) error {
+		if onePage && limit == 0 {
+			limit, remaining = len(cards), len(cards)
+		}
+
+		cards = utils.FilterCards(cards, predicate)
+		if limit == 0 || len(cards) < remaining {
+			remaining -= len(cards)
+			return each(cards)
+		}
+
+		if err := each(cards[:remaining]); err != nil {
+			return err
+		}
+		return api.ErrStopPaging
+	})
+}
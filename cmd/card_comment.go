@@ -0,0 +1,161 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// cardCommentCmd represents the card comment command
+var cardCommentCmd = &cobra.Command{
+	Use:   "comment [card]",
+	Short: "Comment on a Zube card",
+	Long: `Add a comment to a Zube card. The comment body is taken from --body, from a file or stdin
+with --body-file, or else written in $EDITOR. Without a card, the card is picked from a list.
+For example:
+
+  zube card comment 1234 --body "Deployed to staging"
+  ./build.sh 2>&1 | zube card comment 1234 --body-file -`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		body, err := bodyFromFlags(cmd.Flags(), "Comment?", "")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if strings.TrimSpace(body) == "" {
+			log.Fatal("comment cannot be blank")
+		}
+
+		comment, err := api.CreateComment(client, card.Id, body)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Added comment %d to card #%d", comment.Id, card.Number)))
+	},
+}
+
+// cardCommentEditCmd represents the card comment edit command
+var cardCommentEditCmd = &cobra.Command{
+	Use:   "edit <card> <comment-id>",
+	Short: "Edit one of your comments on a Zube card",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, comment, err := fetchOwnComment(client, args[0], args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		body, err := bodyFromFlags(cmd.Flags(), "Comment?", comment.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if strings.TrimSpace(body) == "" {
+			log.Fatal("comment cannot be blank, use `card comment delete` to remove it")
+		}
+
+		if body == comment.Body {
+			fmt.Println("Nothing to change")
+			return
+		}
+
+		if _, err := api.UpdateComment(client, card.Id, comment.Id, body); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Updated comment %d on card #%d", comment.Id, card.Number)))
+	},
+}
+
+// cardCommentDeleteCmd represents the card comment delete command
+var cardCommentDeleteCmd = &cobra.Command{
+	Use:   "delete <card> <comment-id>",
+	Short: "Delete one of your comments on a Zube card",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, comment, err := fetchOwnComment(client, args[0], args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := api.DeleteComment(client, card.Id, comment.Id); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Deleted comment %d from card #%d", comment.Id, card.Number)))
+	},
+}
+
+// Fetches a comment of a card, making sure it was written by the current user
+func fetchOwnComment(client *zube.Client, cardNumber, commentId string) (models.Card, models.Comment, error) {
+	id, err := strconv.Atoi(commentId)
+	if err != nil {
+		return models.Card{}, models.Comment{}, fmt.Errorf("invalid comment ID %q", commentId)
+	}
+
+	card, err := fetchCardByRef(client, cardNumber)
+	if err != nil {
+		return card, models.Comment{}, err
+	}
+
+	comments := client.FetchCardComments(card.Id)
+	comment, ok := lo.Find(comments, func(c models.Comment) bool { return c.Id == id })
+	if !ok {
+		return card, comment, fmt.Errorf("comment %d not found on card #%d", id, card.Number)
+	}
+
+	if person := client.FetchCurrentPerson(); comment.Creator.Id != person.Id {
+		return card, comment, fmt.Errorf("comment %d was written by %s, you can only change your own comments", id, comment.Creator.Name)
+	}
+
+	return card, comment, nil
+}
+
+func init() {
+	cardCmd.AddCommand(cardCommentCmd)
+	cardCommentCmd.AddCommand(cardCommentEditCmd)
+	cardCommentCmd.AddCommand(cardCommentDeleteCmd)
+
+	for _, commentCmd := range []*cobra.Command{cardCommentCmd, cardCommentEditCmd} {
+		commentCmd.Flags().String("body", "", "Comment body (Markdown)")
+		commentCmd.Flags().String("body-file", "", "Read the comment body from a file, or from stdin with `-`")
+		commentCmd.MarkFlagsMutuallyExclusive("body", "body-file")
+	}
+}
@@ -19,148 +19,341 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/logrusorgru/aurora/v4"
 	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/platogo/zube/models"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // cardCreateCmd represents the create command
 var cardCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Zube card",
-	Long:  `Create a brand new Zube card for a given project.`,
+	Long: `Create a brand new Zube card for a given project.
+
+Every field can be given as a flag, using either names or IDs. When running in a terminal,
+you are prompted for the fields that were not given. Otherwise, --project and --title are
+required and all other fields are left empty. For example:
+
+  zube card create --project Backend --workspace Sprint --title "Fix login" --label bug --priority 2
+  git log -1 --format=%B | zube card create --project Backend --title "Release notes" --body-file -`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		flags := cmd.Flags()
+		interactive := term.IsTerminal(int(os.Stdin.Fd()))
+
+		client := newClient()
 
 		projects := client.FetchProjects(&zube.Query{})
+		if len(projects) == 0 {
+			log.Fatal("no projects found, make sure your user has access to at least one Zube project")
+		}
+
 		workspaces := client.FetchWorkspaces(&zube.Query{})
 		sources := client.FetchSources()
 
 		// We need to get the project ID before any other question, since the other prompt option fetchers
 		// rely on it
-		var projectName string
-		projectPrompt := &survey.Select{
-			Message: "Project:",
-			Options: zube.ProjectNames(&projects),
-			Default: projects[0].Name,
+		projectName, _ := flags.GetString("project")
+		defaultProject, hasDefaultProject, err := utils.FindDefault("default_project", projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		survey.AskOne(projectPrompt, &projectName)
-		project, err := zube.GetProjectByName(projectName, &projects)
+		if projectName == "" && !interactive && hasDefaultProject {
+			projectName = defaultProject.Name
+		}
+
+		if projectName == "" {
+			if !interactive {
+				log.Fatal("--project is required when not running in a terminal, or set a default with `zube config set default_project`")
+			}
+
+			projectPrompt := &survey.Select{
+				Message: "Project:",
+				Options: zube.ProjectNames(&projects),
+				Default: projects[0].Name,
+			}
+			if hasDefaultProject {
+				projectPrompt.Default = defaultProject.Name
+			}
+
+			if err := survey.AskOne(projectPrompt, &projectName); err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		}
+
+		project, err := utils.FindExactByNameOrId("project", projectName, projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
 		if err != nil {
-			log.Fatalf(err.Error())
+			log.Fatal(err)
 		}
 
 		labels := client.FetchLabels(project.Id)
 		epics := client.FetchEpics(project.Id)
 		members := client.FetchProjectMembers(project.Id)
 
-		qs := []*survey.Question{
-			{
-				Name: "workspace",
-				Prompt: &survey.Select{
-					Message:  "Workspace:",
-					Options:  zube.WorkspaceNames(&workspaces),
-					Default:  workspaces[0].Name,
-					PageSize: 10,
-				},
-			},
-			{
+		answers := struct {
+			Workspace, Epic, Priority, Title, Description, Source string
+			Labels                                                []string
+			Assignees                                             []string
+		}{Epic: "None", Source: "None", Priority: "None"}
+
+		answers.Workspace, _ = flags.GetString("workspace")
+		answers.Title, _ = flags.GetString("title")
+		answers.Labels, _ = flags.GetStringSlice("label")
+		answers.Assignees, _ = flags.GetStringSlice("assignee")
+
+		if flags.Changed("epic") {
+			answers.Epic, _ = flags.GetString("epic")
+		}
+
+		if flags.Changed("source") {
+			answers.Source, _ = flags.GetString("source")
+		}
+
+		if flags.Changed("priority") {
+			answers.Priority, _ = flags.GetString("priority")
+		}
+
+		if flags.Changed("body") || flags.Changed("body-file") {
+			if answers.Description, err = bodyFromFlags(flags, "Description?", ""); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		// The default workspace only applies to cards of its own project
+		defaultWorkspace, hasDefaultWorkspace, err := utils.FindDefault("default_workspace", workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		if err != nil {
+			log.Fatal(err)
+		}
+		hasDefaultWorkspace = hasDefaultWorkspace && defaultWorkspace.ProjectId == project.Id
+
+		if answers.Workspace == "" && !interactive && hasDefaultWorkspace {
+			answers.Workspace = defaultWorkspace.Name
+		}
+
+		defaultSource, hasDefaultSource, err := utils.FindDefault("default_source", sources,
+			func(s models.Source) int { return s.Id },
+			func(s models.Source) string { return s.Name })
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !flags.Changed("source") && !interactive && hasDefaultSource {
+			answers.Source = defaultSource.Name
+		}
+
+		// The default assignee may not be a member of every project, so it is only skipped with a warning
+		defaultAssignee, hasDefaultAssignee, err := utils.FindDefaultAssignee(client, members)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, aurora.Yellow("Not assigning the default assignee: "+err.Error()))
+		}
+		defaultAssigneeName := zube.MemberNames(&[]models.Member{defaultAssignee})
+
+		if !flags.Changed("assignee") && !interactive && hasDefaultAssignee {
+			answers.Assignees = defaultAssigneeName
+		}
+
+		var qs []*survey.Question
+
+		if answers.Workspace == "" && len(workspaces) > 0 {
+			workspacePrompt := &survey.Select{
+				Message:  "Workspace:",
+				Options:  zube.WorkspaceNames(&workspaces),
+				Default:  workspaces[0].Name,
+				PageSize: 10,
+			}
+			if hasDefaultWorkspace {
+				workspacePrompt.Default = defaultWorkspace.Name
+			}
+
+			qs = append(qs, &survey.Question{Name: "workspace", Prompt: workspacePrompt})
+		}
+
+		if answers.Title == "" {
+			qs = append(qs, &survey.Question{
 				Name:      "title",
 				Prompt:    &survey.Input{Message: "Title?"},
 				Validate:  survey.Required,
 				Transform: survey.Title,
-			},
-			{
+			})
+		}
+
+		if !flags.Changed("body") && !flags.Changed("body-file") {
+			qs = append(qs, &survey.Question{
 				Name:   "description",
 				Prompt: &survey.Editor{Message: "Description?", FileName: "*.md"},
-			},
-			{
+			})
+		}
+
+		if !flags.Changed("label") {
+			qs = append(qs, &survey.Question{
 				Name: "labels",
 				Prompt: &survey.MultiSelect{
 					Message: "Choose labels:",
 					Options: zube.LabelNames(&labels),
 				},
-			},
-			{
-				Name: "assignees",
-				Prompt: &survey.MultiSelect{
-					Message: "Assignees:",
-					Options: zube.MemberNames(&members),
-				},
-			},
-			{
+			})
+		}
+
+		if !flags.Changed("assignee") {
+			assigneesPrompt := &survey.MultiSelect{
+				Message: "Assignees:",
+				Options: zube.MemberNames(&members),
+			}
+			if hasDefaultAssignee {
+				assigneesPrompt.Default = defaultAssigneeName
+			}
+
+			qs = append(qs, &survey.Question{Name: "assignees", Prompt: assigneesPrompt})
+		}
+
+		if !flags.Changed("epic") {
+			qs = append(qs, &survey.Question{
 				Name: "epic",
 				Prompt: &survey.Select{
 					Message: "Epic:",
 					Options: append(zube.EpicTitles(&epics), "None"),
 					Default: "None",
 				},
-			},
-			{
-				Name: "source",
-				Prompt: &survey.Select{
-					Message: "Github source:",
-					Options: append(zube.SourceNames(&sources), "None"),
-					Default: "None",
-				},
-			},
-			{
+			})
+		}
+
+		if !flags.Changed("source") {
+			sourcePrompt := &survey.Select{
+				Message: "Github source:",
+				Options: append(zube.SourceNames(&sources), "None"),
+				Default: "None",
+			}
+			if hasDefaultSource {
+				sourcePrompt.Default = defaultSource.Name
+			}
+
+			qs = append(qs, &survey.Question{Name: "source", Prompt: sourcePrompt})
+		}
+
+		if !flags.Changed("priority") {
+			qs = append(qs, &survey.Question{
 				Name: "priority",
 				Prompt: &survey.Select{
 					Message: "Priority:",
 					Options: []string{"None", "1", "2", "3", "4", "5"},
 					Default: "None",
 				},
-			},
+			})
 		}
 
-		answers := struct {
-			Workspace, Epic, Priority, Title, Description, Source string
-			Labels                                                []int
-			Assignees                                             []string
-		}{}
-
-		err = survey.Ask(qs, &answers)
-		if err != nil {
-			fmt.Println(err.Error())
-			return
+		if interactive {
+			if err = survey.Ask(qs, &answers); err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		} else if answers.Title == "" {
+			log.Fatal("--title is required when not running in a terminal")
 		}
 
-		workspace := zube.GetWorkspaceByName(answers.Workspace, &workspaces)
+		card := models.Card{
+			ProjectId: project.Id,
+			Title:     answers.Title,
+			Body:      answers.Description,
+		}
 
-		epic := zube.GetEpicByTitle(answers.Epic, &epics)
+		if answers.Workspace != "" {
+			workspace, err := utils.FindExactByNameOrId("workspace", answers.Workspace, workspaces,
+				func(w models.Workspace) int { return w.Id },
+				func(w models.Workspace) string { return w.Name })
+			if err != nil {
+				log.Fatal(err)
+			}
+			card.WorkspaceId = workspace.Id
+		}
 
-		source := zube.GetSourceByName(answers.Source, &sources)
+		if !isNone(answers.Epic) {
+			epic, err := utils.FindExactByNameOrId("epic", answers.Epic, epics,
+				func(e models.Epic) int { return e.Id },
+				func(e models.Epic) string { return e.Title })
+			if err != nil {
+				log.Fatal(err)
+			}
+			card.EpicId = epic.Id
+		}
 
-		priority := zube.ParsePriority(answers.Priority)
+		if !isNone(answers.Source) {
+			source, err := utils.FindExactByNameOrId("source", answers.Source, sources,
+				func(s models.Source) int { return s.Id },
+				func(s models.Source) string { return s.Name })
+			if err != nil {
+				log.Fatal(err)
+			}
+			card.GithubIssue = models.GithubIssue{SourceId: source.Id}
+		}
 
-		labels = zube.GetLabelsByIndexes(answers.Labels, labels)
+		if card.Priority, err = parsePriority(answers.Priority); err != nil {
+			log.Fatal(err)
+		}
 
-		assignees := zube.GetMembersByNames(answers.Assignees, members)
+		selectedLabels := make([]models.Label, 0, len(answers.Labels))
+		for _, name := range answers.Labels {
+			label, err := utils.FindExactByNameOrId("label", strings.TrimSpace(name), labels,
+				func(l models.Label) int { return l.Id },
+				func(l models.Label) string { return l.Name })
+			if err != nil {
+				log.Fatal(err)
+			}
+			selectedLabels = append(selectedLabels, label)
+		}
+		card.LabelIds = zube.LabelIds(&selectedLabels)
 
-		card := models.Card{
-			ProjectId:   project.Id,
-			WorkspaceId: workspace.Id,
-			EpicId:      epic.Id,
-			Title:       answers.Title,
-			Priority:    priority,
-			Body:        answers.Description,
-			LabelIds:    zube.LabelIds(&labels),
-			AssigneeIds: zube.MemberIds(&assignees),
-			GithubIssue: models.GithubIssue{SourceId: source.Id}}
+		assignees := make([]models.Member, 0, len(answers.Assignees))
+		for _, name := range answers.Assignees {
+			assignee, err := utils.FindExactByNameOrId("assignee", strings.TrimSpace(name), members,
+				func(m models.Member) int { return zube.MemberIds(&[]models.Member{m})[0] },
+				func(m models.Member) string { return zube.MemberNames(&[]models.Member{m})[0] })
+			if err != nil {
+				log.Fatal(err)
+			}
+			assignees = append(assignees, assignee)
+		}
+		card.AssigneeIds = zube.MemberIds(&assignees)
 
 		newCard := client.CreateCard(&card)
-		account := client.FetchAccounts(
+		accounts := client.FetchAccounts(
 			&zube.Query{
-				Filter: zube.Filter{Where: map[string]any{"id": project.AccountId}}})[0]
+				Filter: zube.Filter{Where: map[string]any{"id": project.AccountId}}})
 
-		fmt.Printf("\nView card on Zube: %s\n", zube.CardUrl(&account, &project, &newCard))
+		if len(accounts) == 0 {
+			fmt.Printf("\nCreated card #%d\n", newCard.Number)
+			return
+		}
+
+		fmt.Printf("\nView card on Zube: %s\n", zube.CardUrl(&accounts[0], &project, &newCard))
 	},
 }
 
 func init() {
 	cardCmd.AddCommand(cardCreateCmd)
+
+	cardCreateCmd.Flags().String("project", "", "Project name or ID")
+	cardCreateCmd.Flags().String("workspace", "", "Workspace name or ID")
+	cardCreateCmd.Flags().String("title", "", "Card title")
+	cardCreateCmd.Flags().String("body", "", "Card body (Markdown)")
+	cardCreateCmd.Flags().String("body-file", "", "Read the card body from a file, or from stdin with `-`")
+	cardCreateCmd.Flags().StringSlice("label", nil, "Label name or ID (repeatable)")
+	cardCreateCmd.Flags().StringSlice("assignee", nil, "Assignee name or ID (repeatable)")
+	cardCreateCmd.Flags().String("epic", "", "Epic title or ID")
+	cardCreateCmd.Flags().String("source", "", "Github source name or ID to open an issue in")
+	cardCreateCmd.Flags().String("priority", "", "Priority from 1 to 5")
+	cardCreateCmd.MarkFlagsMutuallyExclusive("body", "body-file")
 }
@@ -0,0 +1,87 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/deps"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+)
+
+// cardDepsCmd represents the card deps command
+var cardDepsCmd = &cobra.Command{
+	Use:   "deps [card]",
+	Short: "Print the dependency graph of a Zube card",
+	Long: `Print every card that transitively blocks a Zube card, or is blocked by it.
+
+The graph is printed as a tree, or in the Graphviz dot or Mermaid languages, for example:
+
+  zube card deps 1234
+  zube card deps 1234 --format dot | dot -Tsvg > deps.svg`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+
+		client := newClient()
+
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		graph, err := deps.Build(dependencyNode(card), func(id int) ([]deps.Node, []deps.Node, error) {
+			relations, err := api.FetchCardRelations(client, id)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var blockers, blocked []deps.Node
+			for _, relation := range relations {
+				switch relation.Type {
+				case api.RelationBlockedBy:
+					blockers = append(blockers, dependencyNode(relation.Card))
+				case api.RelationBlocks:
+					blocked = append(blocked, dependencyNode(relation.Card))
+				}
+			}
+			return blockers, blocked, nil
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := deps.Render(os.Stdout, graph, format); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func dependencyNode(card models.Card) deps.Node {
+	return deps.Node{Id: card.Id, Label: fmt.Sprintf("#%d %s", card.Number, card.Title), Done: card.Status == "done"}
+}
+
+func init() {
+	cardCmd.AddCommand(cardDepsCmd)
+
+	cardDepsCmd.Flags().String("format", "tree", "Graph format, one of: "+strings.Join(deps.Formats, ", "))
+}
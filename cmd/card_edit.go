@@ -0,0 +1,403 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/markphelps/optional"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// cardEditCmd represents the card edit command
+var cardEditCmd = &cobra.Command{
+	Use:   "edit [card]",
+	Short: "Edit an existing Zube card",
+	Long: `Edit the title, body, priority, labels, assignees, epic or workspace of a Zube card.
+
+Only the fields passed as flags are changed. Without any flags, you are prompted for every field,
+prefilled with the current values of the card. Only fields that actually changed are sent to Zube.
+Without a card, the card is picked from a list.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		workspaces := client.FetchWorkspaces(&zube.Query{})
+		labels := client.FetchLabels(card.ProjectId)
+		epics := client.FetchEpics(card.ProjectId)
+		members := client.FetchProjectMembers(card.ProjectId)
+		sources := client.FetchSources()
+
+		edit := newCardEdit(&card, &workspaces, &labels, &epics, &members)
+
+		// Global flags such as --output or --profile don't count as fields to change
+		if !lo.SomeBy(cardEditFields, cmd.Flags().Changed) {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				log.Fatal("no fields to change given, and cannot prompt for them without a terminal")
+			}
+
+			if err := edit.ask(&card, &workspaces, &labels, &epics, &members, &sources); err != nil {
+				fmt.Println(err.Error())
+				return
+			}
+		} else if err := edit.readFlags(cmd); err != nil {
+			log.Fatal(err)
+		}
+
+		changes, err := edit.changes(&card, &workspaces, &labels, &epics, &members, &sources)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("Nothing to change")
+			return
+		}
+
+		updated, err := api.UpdateCard(client, card.Id, changes)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		changed := lo.Keys(changes)
+		sort.Strings(changed)
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Updated card #%d:", updated.Number)), strings.Join(changed, ", "))
+	},
+}
+
+// Flags of the fields a card edit can change
+var cardEditFields = []string{"title", "body", "workspace", "epic", "source", "priority", "label", "assignee"}
+
+// Editable card fields, in the same shape as the answers of the `card create` prompts
+type cardEdit struct {
+	Workspace, Epic, Priority, Title, Description, Source string
+	Labels                                                []string
+	Assignees                                             []string
+}
+
+// Prefills a card edit with the current values of the card
+func newCardEdit(card *models.Card, workspaces *[]models.Workspace, labels *[]models.Label, epics *[]models.Epic, members *[]models.Member) cardEdit {
+	edit := cardEdit{
+		Title:       card.Title,
+		Description: card.Body,
+		Epic:        "None",
+		Priority:    "None",
+		Source:      "None",
+	}
+
+	if workspace, ok := lo.Find(*workspaces, func(w models.Workspace) bool { return w.Id == card.WorkspaceId }); ok {
+		edit.Workspace = workspace.Name
+	}
+
+	if epic, ok := lo.Find(*epics, func(e models.Epic) bool { return e.Id == card.EpicId }); ok && card.EpicId != 0 {
+		edit.Epic = epic.Title
+	}
+
+	if priority := card.Priority.OrElse(0); priority != 0 {
+		edit.Priority = fmt.Sprint(priority)
+	}
+
+	if card.GithubIssue.Id != 0 {
+		edit.Source = card.GithubIssue.Source.Name
+	}
+
+	for _, label := range *labels {
+		if lo.Contains(card.LabelIds, label.Id) {
+			edit.Labels = append(edit.Labels, label.Name)
+		}
+	}
+
+	for _, member := range *members {
+		if lo.Contains(card.AssigneeIds, zube.MemberIds(&[]models.Member{member})[0]) {
+			edit.Assignees = append(edit.Assignees, zube.MemberNames(&[]models.Member{member})...)
+		}
+	}
+
+	return edit
+}
+
+// Asks for every editable field, using the current values as defaults
+func (edit *cardEdit) ask(card *models.Card, workspaces *[]models.Workspace, labels *[]models.Label, epics *[]models.Epic, members *[]models.Member, sources *[]models.Source) error {
+	qs := []*survey.Question{
+		{
+			Name: "workspace",
+			Prompt: &survey.Select{
+				Message:  "Workspace:",
+				Options:  zube.WorkspaceNames(workspaces),
+				Default:  edit.Workspace,
+				PageSize: 10,
+			},
+		},
+		{
+			Name:     "title",
+			Prompt:   &survey.Input{Message: "Title?", Default: edit.Title},
+			Validate: survey.Required,
+		},
+		{
+			Name: "description",
+			Prompt: &survey.Editor{
+				Message:       "Description?",
+				FileName:      "*.md",
+				Default:       edit.Description,
+				AppendDefault: true,
+				HideDefault:   true,
+			},
+		},
+		{
+			Name: "labels",
+			Prompt: &survey.MultiSelect{
+				Message: "Choose labels:",
+				Options: zube.LabelNames(labels),
+				Default: edit.Labels,
+			},
+		},
+		{
+			Name: "assignees",
+			Prompt: &survey.MultiSelect{
+				Message: "Assignees:",
+				Options: zube.MemberNames(members),
+				Default: edit.Assignees,
+			},
+		},
+		{
+			Name: "epic",
+			Prompt: &survey.Select{
+				Message: "Epic:",
+				Options: append(zube.EpicTitles(epics), "None"),
+				Default: edit.Epic,
+			},
+		},
+		{
+			Name: "priority",
+			Prompt: &survey.Select{
+				Message: "Priority:",
+				Options: []string{"None", "1", "2", "3", "4", "5"},
+				Default: edit.Priority,
+			},
+		},
+	}
+
+	// A Github issue can only be opened for cards that are not linked to one yet
+	if card.GithubIssue.Id == 0 {
+		qs = append(qs, &survey.Question{
+			Name: "source",
+			Prompt: &survey.Select{
+				Message: "Github source:",
+				Options: append(zube.SourceNames(sources), "None"),
+				Default: edit.Source,
+			},
+		})
+	}
+
+	return survey.Ask(qs, edit)
+}
+
+// Overrides the fields that were explicitly passed as flags
+func (edit *cardEdit) readFlags(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("workspace") {
+		edit.Workspace, _ = flags.GetString("workspace")
+	}
+
+	if flags.Changed("title") {
+		edit.Title, _ = flags.GetString("title")
+	}
+
+	if flags.Changed("body") {
+		edit.Description, _ = flags.GetString("body")
+	}
+
+	if flags.Changed("epic") {
+		edit.Epic, _ = flags.GetString("epic")
+	}
+
+	if flags.Changed("source") {
+		edit.Source, _ = flags.GetString("source")
+	}
+
+	if flags.Changed("priority") {
+		edit.Priority, _ = flags.GetString("priority")
+	}
+
+	if flags.Changed("label") {
+		edit.Labels, _ = flags.GetStringSlice("label")
+	}
+
+	if flags.Changed("assignee") {
+		edit.Assignees, _ = flags.GetStringSlice("assignee")
+	}
+
+	if edit.Title == "" {
+		return fmt.Errorf("title cannot be blank")
+	}
+
+	return nil
+}
+
+// Compares the edit against the card and returns only the changed attributes, keyed by their Zube API names.
+// Every workspace, epic, source, label and assignee must exist, so a typo never clears a field.
+func (edit *cardEdit) changes(card *models.Card, workspaces *[]models.Workspace, labels *[]models.Label, epics *[]models.Epic, members *[]models.Member, sources *[]models.Source) (map[string]any, error) {
+	changes := make(map[string]any)
+
+	if edit.Title != card.Title {
+		changes["title"] = edit.Title
+	}
+
+	// Editors end the body with a newline, which doesn't change it
+	if strings.TrimRight(edit.Description, "\r\n") != strings.TrimRight(card.Body, "\r\n") {
+		changes["body"] = edit.Description
+	}
+
+	if edit.Workspace != "" {
+		workspace, err := utils.FindExactByNameOrId("workspace", edit.Workspace, *workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		if err != nil {
+			return nil, err
+		}
+		if workspace.Id != card.WorkspaceId {
+			changes["workspace_id"] = workspace.Id
+		}
+	}
+
+	epicId := 0
+	if !isNone(edit.Epic) {
+		epic, err := utils.FindExactByNameOrId("epic", edit.Epic, *epics,
+			func(e models.Epic) int { return e.Id },
+			func(e models.Epic) string { return e.Title })
+		if err != nil {
+			return nil, err
+		}
+		epicId = epic.Id
+	}
+	if epicId != card.EpicId {
+		if epicId == 0 {
+			changes["epic_id"] = nil
+		} else {
+			changes["epic_id"] = epicId
+		}
+	}
+
+	priority, err := parsePriority(edit.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if priority.OrElse(0) != card.Priority.OrElse(0) {
+		if priority.Present() {
+			changes["priority"] = priority.OrElse(0)
+		} else {
+			changes["priority"] = nil
+		}
+	}
+
+	labelIds := make([]int, 0, len(edit.Labels))
+	for _, name := range edit.Labels {
+		label, err := utils.FindExactByNameOrId("label", strings.TrimSpace(name), *labels,
+			func(l models.Label) int { return l.Id },
+			func(l models.Label) string { return l.Name })
+		if err != nil {
+			return nil, err
+		}
+		labelIds = append(labelIds, label.Id)
+	}
+	if !sameIds(labelIds, card.LabelIds) {
+		changes["label_ids"] = labelIds
+	}
+
+	assigneeIds := make([]int, 0, len(edit.Assignees))
+	for _, name := range edit.Assignees {
+		assignee, err := utils.FindExactByNameOrId("assignee", strings.TrimSpace(name), *members,
+			func(m models.Member) int { return zube.MemberIds(&[]models.Member{m})[0] },
+			func(m models.Member) string { return zube.MemberNames(&[]models.Member{m})[0] })
+		if err != nil {
+			return nil, err
+		}
+		assigneeIds = append(assigneeIds, zube.MemberIds(&[]models.Member{assignee})[0])
+	}
+	if !sameIds(assigneeIds, card.AssigneeIds) {
+		changes["assignee_ids"] = assigneeIds
+	}
+
+	if !isNone(edit.Source) && card.GithubIssue.Id == 0 {
+		source, err := utils.FindExactByNameOrId("source", edit.Source, *sources,
+			func(s models.Source) int { return s.Id },
+			func(s models.Source) string { return s.Name })
+		if err != nil {
+			return nil, err
+		}
+		changes["github_issue"] = map[string]any{"source_id": source.Id}
+	}
+
+	return changes, nil
+}
+
+// Checks for the "None" choice of optional fields such as the epic, in any case
+func isNone(value string) bool {
+	return strings.EqualFold(value, "none")
+}
+
+// Parses a priority from 1 to 5, or "None" for no priority
+func parsePriority(value string) (optional.Int, error) {
+	if isNone(value) {
+		return optional.Int{}, nil
+	}
+
+	priority, err := strconv.Atoi(value)
+	if err != nil || priority < 1 || priority > 5 {
+		return optional.Int{}, fmt.Errorf("invalid priority %q, must be a number from 1 to 5 or \"None\"", value)
+	}
+	return optional.NewInt(priority), nil
+}
+
+// Checks whether two ID slices contain the same IDs, regardless of order
+func sameIds(a, b []int) bool {
+	a, b = lo.Uniq(a), lo.Uniq(b)
+	return len(a) == len(b) && len(lo.Intersect(a, b)) == len(a)
+}
+
+func init() {
+	cardCmd.AddCommand(cardEditCmd)
+
+	cardEditCmd.Flags().String("title", "", "New card title")
+	cardEditCmd.Flags().String("body", "", "New card body (Markdown)")
+	cardEditCmd.Flags().String("workspace", "", "Move the card to the workspace with this name")
+	cardEditCmd.Flags().String("epic", "", "Epic title, or \"None\" to remove the card from its epic")
+	cardEditCmd.Flags().String("source", "", "Github source to open an issue in, if the card has none yet")
+	cardEditCmd.Flags().String("priority", "", "Priority from 1 to 5, or \"None\"")
+	cardEditCmd.Flags().StringSlice("label", nil, "Label names to set, replacing the current ones (repeatable)")
+	cardEditCmd.Flags().StringSlice("assignee", nil, "Assignee names to set, replacing the current ones (repeatable)")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/markphelps/optional"
+	"github.com/platogo/zube/models"
+)
+
+func TestCardEditChanges(t *testing.T) {
+	card := models.Card{Title: "Fix login", Body: "Steps", WorkspaceId: 1, EpicId: 5, LabelIds: []int{10}, Priority: optional.NewInt(2)}
+	workspaces := []models.Workspace{{Id: 1, Name: "Sprint"}, {Id: 2, Name: "Backlog"}}
+	labels := []models.Label{{Id: 10, Name: "bug"}, {Id: 11, Name: "feature"}}
+	epics := []models.Epic{{Id: 5, Title: "Auth"}}
+	var members []models.Member
+	var sources []models.Source
+
+	tests := []struct {
+		name    string
+		edit    cardEdit
+		want    map[string]any
+		wantErr bool
+	}{
+		{"unchanged", cardEdit{Title: "Fix login", Description: "Steps\n", Workspace: "Sprint", Epic: "Auth", Priority: "2", Source: "None", Labels: []string{"bug"}}, map[string]any{}, false},
+		{"cleared", cardEdit{Title: "Fix login", Description: "Steps", Workspace: "Backlog", Epic: "none", Priority: "None", Source: "None", Labels: []string{}},
+			map[string]any{"workspace_id": 2, "epic_id": nil, "priority": nil, "label_ids": []int{}}, false},
+		{"body", cardEdit{Title: "Fix login", Description: "Steps to reproduce\n", Epic: "Auth", Priority: "2", Source: "None", Labels: []string{"bug"}},
+			map[string]any{"body": "Steps to reproduce\n"}, false},
+		{"unknown label", cardEdit{Title: "Fix login", Epic: "Auth", Priority: "2", Source: "None", Labels: []string{"bgu"}}, nil, true},
+		{"unknown workspace", cardEdit{Title: "Fix login", Workspace: "Kanban", Epic: "Auth", Priority: "2", Source: "None"}, nil, true},
+		{"unknown epic", cardEdit{Title: "Fix login", Epic: "Billing", Priority: "2", Source: "None"}, nil, true},
+		{"invalid priority", cardEdit{Title: "Fix login", Epic: "Auth", Priority: "abc", Source: "None"}, nil, true},
+		{"priority out of range", cardEdit{Title: "Fix login", Epic: "Auth", Priority: "6", Source: "None"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := test.edit.changes(&card, &workspaces, &labels, &epics, &members, &sources)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got changes %v", changes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(changes, test.want) {
+				t.Errorf("expected %v, got %v", test.want, changes)
+			}
+		})
+	}
+}
+
+func TestSameIds(t *testing.T) {
+	tests := []struct {
+		a, b []int
+		want bool
+	}{
+		{[]int{1, 2}, []int{2, 1}, true},
+		{[]int{1, 1}, []int{1}, true},
+		{[]int{1, 1}, []int{1, 2}, false},
+		{[]int{}, nil, true},
+		{[]int{1}, nil, false},
+	}
+
+	for _, test := range tests {
+		if got := sameIds(test.a, test.b); got != test.want {
+			t.Errorf("sameIds(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
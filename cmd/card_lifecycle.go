@@ -0,0 +1,145 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// cardArchiveCmd represents the card archive command
+var cardArchiveCmd = newCardLifecycleCmd("archive", "Archive", "Archived", api.ArchiveCard)
+
+// cardCloseCmd represents the card close command
+var cardCloseCmd = newCardLifecycleCmd("close", "Close", "Closed", api.CloseCard)
+
+// cardReopenCmd represents the card reopen command
+var cardReopenCmd = newCardLifecycleCmd("reopen", "Reopen", "Reopened", api.ReopenCard)
+
+// Builds a command that applies a lifecycle action either to a single card given by a reference,
+// or to every card matching the `card ls` filter flags.
+func newCardLifecycleCmd(name, verb, pastVerb string, action func(*zube.Client, int) (models.Card, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " [card]",
+		Short: verb + " a card, or all cards matching the given filters",
+		Long: fmt.Sprintf(`%s a single card, or every card matching the same filter flags as `+"`card ls`"+`.
+
+In bulk mode, the matching cards are listed first and you are asked for confirmation,
+unless --yes is given. For example:
+
+  zube card %s 1234
+  zube card %s --workspace-id 42 --category Done --yes`, verb, name, name),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+
+			if len(args) == 1 {
+				card, err := fetchCardByRef(client, args[0])
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				if card, err = action(client, card.Id); err != nil {
+					log.Fatal(err)
+				}
+
+				fmt.Println(aurora.Green(fmt.Sprintf("%s card #%d", pastVerb, card.Number)))
+				return
+			}
+
+			// The default project and workspace don't count as filters, so a whole project is never changed by accident
+			filtered := false
+			cmd.LocalFlags().Visit(func(flag *pflag.Flag) { filtered = filtered || flag.Name != "yes" })
+
+			query, predicate, err := cardQueryFromFlags(client, cmd.LocalFlags(), false, utils.ExactMatch)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !filtered || len(query.Filter.Where) == 0 && predicate == nil {
+				log.Fatal("either a card or at least one filter is required")
+			}
+			if query.Filter.Select != nil {
+				query.Filter.Select = append(query.Filter.Select, "id")
+			}
+
+			// Bulk changes apply to every matching card, not just the first page
+			var cards []models.Card
+			opts := api.PageOptions{All: true, PerPage: 100, Parallel: 4}
+			err = api.EachPage(client, "/api/cards", &query, opts, func(page []models.Card) error {
+				cards = append(cards, utils.FilterCards(page, predicate)...)
+				return nil
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			if len(cards) == 0 {
+				fmt.Println("no matching cards")
+				return
+			}
+
+			utils.LoadEpicTitles(client, cards)
+			utils.PrintCards(&cards)
+
+			if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+				if !term.IsTerminal(int(os.Stdin.Fd())) {
+					log.Fatal("refusing to change several cards without a terminal to confirm, pass --yes to skip confirmation")
+				}
+
+				confirmed := false
+				prompt := &survey.Confirm{Message: fmt.Sprintf("%s %d cards?", verb, len(cards))}
+				if err := survey.AskOne(prompt, &confirmed); err != nil || !confirmed {
+					fmt.Println("Aborted")
+					return
+				}
+			}
+
+			failed := 0
+			for _, card := range cards {
+				if _, err := action(client, card.Id); err != nil {
+					fmt.Println(aurora.Red(fmt.Sprintf("#%d: %s", card.Number, err)))
+					failed++
+				}
+			}
+
+			fmt.Println(aurora.Green(fmt.Sprintf("%s %d of %d cards", pastVerb, len(cards)-failed, len(cards))))
+
+			if failed > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func init() {
+	for _, lifecycleCmd := range []*cobra.Command{cardArchiveCmd, cardCloseCmd, cardReopenCmd} {
+		cardCmd.AddCommand(lifecycleCmd)
+
+		addCardQueryFlags(lifecycleCmd.Flags())
+		lifecycleCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation in bulk mode")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// The flags of `card link`, and the relationships they create
+var cardLinkFlags = []struct{ flag, relation, description string }{
+	{"blocks", api.RelationBlocks, "blocks"},
+	{"blocked-by", api.RelationBlockedBy, "is blocked by"},
+	{"related", api.RelationRelated, "is related to"},
+}
+
+// cardLinkCmd represents the card link command
+var cardLinkCmd = &cobra.Command{
+	Use:   "link <card> (--blocks|--blocked-by|--related) <card>",
+	Short: "Relate a Zube card to another card",
+	Long: `Relate a Zube card to another card, either as blocking it, being blocked by it, or just being related.
+For example:
+
+  zube card link 1234 --blocks 1240
+  zube card link Backend/1234 --related Frontend/87`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var other, relation, description string
+		for _, link := range cardLinkFlags {
+			if value, _ := cmd.Flags().GetString(link.flag); value != "" {
+				if other != "" {
+					log.Fatal("only one of --blocks, --blocked-by and --related can be given")
+				}
+				other, relation, description = value, link.relation, link.description
+			}
+		}
+		if other == "" {
+			log.Fatal("one of --blocks, --blocked-by or --related is required")
+		}
+
+		client := newClient()
+
+		card, err := fetchCardByRef(client, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		otherCard, err := fetchCardByRef(client, other)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if card.Id == otherCard.Id {
+			log.Fatal("a card cannot be related to itself")
+		}
+
+		if _, err := api.CreateCardRelation(client, card.Id, otherCard.Id, relation); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Card #%d %s card #%d", card.Number, description, otherCard.Number)))
+	},
+}
+
+func init() {
+	cardCmd.AddCommand(cardLinkCmd)
+
+	for _, link := range cardLinkFlags {
+		cardLinkCmd.Flags().String(link.flag, "", fmt.Sprintf("The card that this card %s", link.description))
+	}
+}
@@ -4,10 +4,15 @@ Copyright © 2022 Daniils Petrovs <daniils@platogo.com>
 package cmd
 
 import (
-	"github.com/platogo/zube"
+	"errors"
+	"fmt"
+	"log"
+
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // Used to list various Zube entities, depending on the parent command name
@@ -15,36 +20,132 @@ var cardLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List cards with given filters",
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		client := newClient()
 
-		query := utils.NewQueryFromFlags(cmd.LocalFlags())
+		query, predicate, err := cardQueryFromFlags(client, cmd.LocalFlags(), false, utils.PartialMatch)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-		var cards []models.Card
+		sortField, desc, err := cardSortFromFlags(cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		if groupBy != "" {
+			if err := utils.ValidateCardGroupField(groupBy); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		// Sorting and grouping may need any card attribute, not just the selected table columns
+		if sortField != "" || groupBy != "" {
+			query.Filter.Select = nil
+		}
+
+		path := "/api/cards"
+		projectId, _ := query.Filter.Where["project_id"].(int)
 
-		if projectId, err := cmd.Flags().GetInt("project-id"); err == nil && projectId != 0 {
+		if projectId != 0 {
 			query.Direction = "desc"
 			query.Order.By = "milestone"
+			path = fmt.Sprintf("/api/projects/%d/cards", projectId)
+		}
+
+		// Sorting by the API as well makes --limit and --page return the first cards in sort order
+		if sortField != "" {
+			query.Order.By = utils.CardSortFields[sortField]
+			query.Direction = lo.Ternary(desc, "desc", "asc")
+		}
+
+		opts, paginated := pageOptionsFromFlags(cmd.Flags())
+
+		// Client-side filters page through the cards until enough of them match
+		paginated = paginated || predicate != nil
+
+		// Sorted and grouped listings need every card before printing, so they are never streamed
+		if paginated && sortField == "" && groupBy == "" {
+			if err := streamCards(client, path, &query, predicate, opts); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		var cards []models.Card
+
+		if paginated {
+			err = eachCardPage(client, path, &query, predicate, opts, func(page []models.Card) error {
+				cards = append(cards, page...)
+				return nil
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else if projectId != 0 {
 			cards = client.FetchProjectCards(projectId, &query)
 		} else {
 			cards = client.FetchCards(&query)
 		}
 
+		if sortField != "" {
+			utils.SortCards(cards, sortField, desc)
+		}
+
+		utils.LoadEpicTitles(client, cards)
+
+		if groupBy != "" {
+			groups, err := utils.GroupCards(client, cards, groupBy)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			utils.PrintCardGroups(&groups, groupBy)
+			return
+		}
+
 		utils.PrintItems(&cards)
 	},
 }
 
+// Returns the --sort field and whether to sort descending. Without --asc or --desc, cards are sorted
+// ascending, except by `updated`, which lists the most recently updated cards first.
+func cardSortFromFlags(flags *pflag.FlagSet) (string, bool, error) {
+	field, _ := flags.GetString("sort")
+	asc, _ := flags.GetBool("asc")
+	desc, _ := flags.GetBool("desc")
+
+	if field == "" {
+		if asc || desc {
+			return "", false, errors.New("--asc and --desc require --sort")
+		}
+		return "", false, nil
+	}
+
+	if err := utils.ValidateCardSortField(field); err != nil {
+		return "", false, err
+	}
+
+	if asc && desc {
+		return "", false, errors.New("--asc and --desc cannot be used together")
+	}
+
+	if !asc && !desc {
+		desc = field == "updated"
+	}
+
+	return field, desc, nil
+}
+
 func init() {
 	cardCmd.AddCommand(cardLsCmd)
 
-	cardLsCmd.Flags().Int("id", 0, "Filter by card internal ID")
-	cardLsCmd.Flags().String("category", "", "Filter by category name")
-	cardLsCmd.Flags().Int("epic-id", 0, "Filter by epic ID")
-	cardLsCmd.Flags().Int("number", 0, "Filter by card number")
-	cardLsCmd.Flags().Int("priority", -1, "Filter by priority")
-	cardLsCmd.Flags().Int("project-id", 0, "Filter by project ID")
-	cardLsCmd.Flags().Int("sprint-id", 0, "Filter by sprint ID")
-	cardLsCmd.Flags().Int("workspace-id", 0, "Filter by workspace ID")
-	cardLsCmd.Flags().String("assignee-id", "", "Filter by assignee")
-	cardLsCmd.Flags().String("state", "", "Filter by card state")
-	cardLsCmd.Flags().String("status", "", "Filter by card status")
+	addCardQueryFlags(cardLsCmd.Flags())
+	addPaginationFlags(cardLsCmd.Flags())
+	addCardColumnsFlag(cardLsCmd.Flags())
+
+	cardLsCmd.Flags().String("sort", "", "Sort cards by number, priority, status, updated or title")
+	cardLsCmd.Flags().Bool("asc", false, "Sort in ascending order")
+	cardLsCmd.Flags().Bool("desc", false, "Sort in descending order")
+	cardLsCmd.Flags().String("group-by", "", "Group cards by status, category, assignee, epic or label")
 }
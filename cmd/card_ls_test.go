@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
 	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/pflag"
 )
 
 func TestNewQueryFromFlags(t *testing.T) {
@@ -26,4 +33,63 @@ func TestNewQueryFromFlags(t *testing.T) {
 	if !reflect.DeepEqual(res, want) {
 		t.Errorf("want does not match result, \nexpected: %+v \ngot: %+v", want, res)
 	}
+
+	statusFlags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	statusFlags.StringSlice("status", nil, "")
+	statusFlags.Set("status", "In Progress")
+	if status := utils.NewQueryFromFlags(statusFlags).Filter.Where["status"]; status != "in_progress" {
+		t.Errorf("expected the status in snake case, got %v", status)
+	}
+}
+
+func TestEachCardPage(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		result := api.Page[models.Card]{Pagination: api.Pagination{Page: page, PerPage: 10, TotalPages: 3, Total: 25}}
+		for i := (page-1)*10 + 1; i <= 25 && i <= page*10; i++ {
+			result.Data = append(result.Data, models.Card{Number: i})
+		}
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	host := api.ZubeHost
+	api.ZubeHost = server.URL
+	defer func() { api.ZubeHost = host }()
+
+	even := func(card *models.Card) bool { return card.Number%2 == 0 }
+
+	tests := []struct {
+		name     string
+		opts     api.PageOptions
+		want     int
+		requests int
+	}{
+		{"limit counts matching cards", api.PageOptions{Limit: 7}, 7, 2},
+		{"no limit fills one page", api.PageOptions{}, 10, 2},
+		{"all fetches every page", api.PageOptions{All: true}, 12, 3},
+		{"a single page", api.PageOptions{Page: 2}, 5, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests = 0
+			var numbers []int
+			err := eachCardPage(&zube.Client{}, "/api/cards", &zube.Query{}, even, test.opts, func(cards []models.Card) error {
+				for _, card := range cards {
+					numbers = append(numbers, card.Number)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(numbers) != test.want || requests != test.requests {
+				t.Errorf("expected %d cards in %d requests, got %v in %d", test.want, test.requests, numbers, requests)
+			}
+		})
+	}
 }
@@ -0,0 +1,145 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// cardMoveCmd represents the card move command
+var cardMoveCmd = &cobra.Command{
+	Use:   "move [card]",
+	Short: "Move a Zube card to another category or status",
+	Long: `Move a Zube card between the categories (board columns) of a workspace, or change its status.
+
+Category names are matched case-insensitively against the categories of the card's workspace,
+or of the workspace given with --workspace. Without a card, the card is picked from a list.
+For example:
+
+  zube card move 1234 --category "In Progress"
+  zube card move 1234 --category Done --position bottom
+  zube card move 1234 --status in_review`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		categoryName, _ := cmd.Flags().GetString("category")
+		workspaceName, _ := cmd.Flags().GetString("workspace")
+		position, _ := cmd.Flags().GetString("position")
+		status, _ := cmd.Flags().GetString("status")
+
+		if categoryName == "" && status == "" {
+			log.Fatal("either --category or --status is required")
+		}
+
+		if position != "top" && position != "bottom" {
+			log.Fatalf("invalid position %q, must be either top or bottom", position)
+		}
+
+		client := newClient()
+
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if categoryName != "" {
+			workspaceId := card.WorkspaceId
+
+			if workspaceName != "" {
+				workspaces := client.FetchWorkspaces(&zube.Query{})
+				workspace, err := utils.FindExactByNameOrId("workspace", workspaceName, workspaces,
+					func(w models.Workspace) int { return w.Id },
+					func(w models.Workspace) string { return w.Name })
+				if err != nil {
+					log.Fatal(err)
+				}
+				workspaceId = workspace.Id
+			}
+
+			if workspaceId == 0 {
+				log.Fatal("card is not in a workspace, use --workspace to choose one")
+			}
+
+			category, err := findCategory(client, workspaceId, categoryName)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			destination := api.Destination{Type: "category", Name: category.Name, WorkspaceId: workspaceId}
+
+			if position == "bottom" {
+				query := zube.Query{Filter: zube.Filter{Where: map[string]any{
+					"workspace_id":  workspaceId,
+					"category_name": category.Name,
+				}}}
+				if destination.Position, err = api.CountCards(client, &query); err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			if card, err = api.MoveCard(client, card.Id, destination); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Println(aurora.Green(fmt.Sprintf("Moved card #%d to %s", card.Number, category.Name)))
+		}
+
+		if status != "" {
+			status = utils.TitleCaseToSnakeCase(status)
+
+			if card, err = api.UpdateCard(client, card.Id, map[string]any{"status": status}); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Println(aurora.Green(fmt.Sprintf("Changed status of card #%d to %s", card.Number, utils.SnakeCaseToTitleCase(status))))
+		}
+	},
+}
+
+// Finds a workspace category by its case-insensitive name
+func findCategory(client *zube.Client, workspaceId int, name string) (api.Category, error) {
+	categories, err := api.FetchCategories(client, workspaceId)
+	if err != nil {
+		return api.Category{}, err
+	}
+
+	category, ok := lo.Find(categories, func(c api.Category) bool { return strings.EqualFold(c.Name, name) })
+	if !ok {
+		names := lo.Map(categories, func(c api.Category, _ int) string { return c.Name })
+		return category, fmt.Errorf("category %q not found, available categories: %s", name, strings.Join(names, ", "))
+	}
+
+	return category, nil
+}
+
+func init() {
+	cardCmd.AddCommand(cardMoveCmd)
+
+	cardMoveCmd.Flags().String("category", "", "Name of the category to move the card to")
+	cardMoveCmd.Flags().String("workspace", "", "Name or ID of the workspace to move the card to (default is the card's workspace)")
+	cardMoveCmd.Flags().String("position", "top", "Position in the category, either top or bottom")
+	cardMoveCmd.Flags().String("status", "", "Change the card status, e.g. in_progress or \"In Review\"")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube"
+	"github.com/spf13/cobra"
+)
+
+// cardOpenCmd represents the card open command
+var cardOpenCmd = &cobra.Command{
+	Use:   "open [card]",
+	Short: "Open a Zube card in the browser",
+	Long: `Open a Zube card in the browser given by $BROWSER, or else the default browser.
+With --print, the URL is only printed, e.g. when working over SSH. Without a card, the card is picked from a list.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account, project, err := fetchCardProject(client, &card)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		openUrl(cmd, zube.CardUrl(&account, &project, &card))
+	},
+}
+
+func init() {
+	cardCmd.AddCommand(cardOpenCmd)
+	addOpenFlags(cardOpenCmd.Flags())
+}
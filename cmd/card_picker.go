@@ -0,0 +1,107 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/tui"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+)
+
+// The most recently updated cards the picker loads, enough to find older cards without loading every card
+const pickerCardLimit = 1000
+
+// Lets the user pick a card with a fuzzy finder, starting with the open cards assigned to them.
+// The highlighted card is previewed as `card view` shows it.
+func pickCard(client *zube.Client) (models.Card, error) {
+	// The client is not safe for concurrent use, and items and previews load in the background
+	var mu sync.Mutex
+
+	cardItems := func(where map[string]any) func() ([]tui.PickerItem, error) {
+		return func() ([]tui.PickerItem, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			query := zube.Query{Filter: zube.Filter{Where: where}, Direction: "desc"}
+			query.Order.By = "updated_at"
+
+			var cards []models.Card
+			opts := api.PageOptions{All: true, PerPage: 100, Limit: pickerCardLimit}
+			err := api.EachPage(client, "/api/cards", &query, opts, func(page []models.Card) error {
+				cards = append(cards, page...)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			items := make([]tui.PickerItem, len(cards))
+			for i, card := range cards {
+				items[i] = tui.PickerItem{
+					Id:    strconv.Itoa(card.Id),
+					Label: fmt.Sprintf("#%d %s (%s)", card.Number, card.Title, utils.SnakeCaseToTitleCase(card.Status)),
+					Value: card,
+				}
+			}
+			return items, nil
+		}
+	}
+
+	mu.Lock()
+	me := client.FetchCurrentPerson()
+	mu.Unlock()
+
+	picker := tui.Picker{
+		Prompt: "Card:",
+		Sources: []tui.PickerSource{
+			{Name: "My cards", Load: cardItems(map[string]any{"assignee_ids": []string{strconv.Itoa(me.Id)}, "state": "open"})},
+			{Name: "All open cards", Load: cardItems(map[string]any{"state": "open"})},
+		},
+		Preview: func(item tui.PickerItem, width int) string {
+			mu.Lock()
+			defer mu.Unlock()
+
+			card := item.Value.(models.Card)
+			account, project, err := fetchCardProject(client, &card)
+			if err != nil {
+				return err.Error()
+			}
+
+			var preview bytes.Buffer
+			utils.FprintCard(&preview, width, &account, &project, &card)
+			return preview.String()
+		},
+	}
+
+	item, err := picker.Run()
+	if errors.Is(err, tui.ErrCanceled) {
+		return models.Card{}, errors.New("no card selected")
+	}
+	if err != nil {
+		return models.Card{}, err
+	}
+
+	return item.Value.(models.Card), nil
+}
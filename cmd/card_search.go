@@ -18,10 +18,10 @@ package cmd
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
-	"github.com/platogo/zube"
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -32,51 +32,70 @@ var searchCmd = &cobra.Command{
 	Short: "Search Zube cards",
 	Long:  `Search all Zube cards using a fuzzy search query.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var searchQuery string
+		zqlQuery, _ := cmd.Flags().GetString("query")
 
-		switch {
-		case len(args) == 0:
+		searchQuery, ok := searchTerms(args, zqlQuery)
+		if !ok {
 			fmt.Println("please provide a search query")
-		case len(args) > 1:
-			searchQuery = strings.Join(args, " ")
-		default:
-			searchQuery = args[0]
+			return
+		}
+
+		client := newClient()
+		query, predicate, err := cardQueryFromFlags(client, cmd.LocalFlags(), true, utils.PartialMatch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		query.Search = strings.TrimSpace(searchQuery + " " + query.Search)
+
+		// Client-side filters page through the cards until enough of them match
+		if opts, paginated := pageOptionsFromFlags(cmd.Flags()); paginated || predicate != nil {
+			if err := streamCards(client, "/api/cards", &query, predicate, opts); err != nil {
+				log.Fatal(err)
+			}
+			return
 		}
 
-		client, _ := zube.NewClient()
-		query := utils.NewQueryFromFlags(cmd.LocalFlags())
-		query.Search = searchQuery
 		cards := client.SearchCards(&query)
 
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&cards)
+			return
+		}
+
 		switch len(cards) {
 		case 0:
 			fmt.Println("no results")
 		case 1:
 			card := cards[0]
-			projectQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": card.ProjectId}}}
-			project := client.FetchProjects(&projectQueryById)[0]
-			accountQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": project.AccountId}}}
-			account := client.FetchAccounts(&accountQueryById)[0]
+			account, project, err := fetchCardProject(client, &card)
+			if err != nil {
+				log.Fatal(err)
+			}
 			utils.PrintCard(&account, &project, &card)
 		default:
+			utils.LoadEpicTitles(client, cards)
 			utils.PrintCards(&cards)
 		}
 		time.Sleep(time.Second)
 	},
 }
 
+// Returns the search words given as arguments, which are optional when a ZQL query is given
+func searchTerms(args []string, zqlQuery string) (string, bool) {
+	switch {
+	case len(args) == 0:
+		return "", zqlQuery != ""
+	case len(args) > 1:
+		return strings.Join(args, " "), true
+	default:
+		return args[0], true
+	}
+}
+
 func init() {
 	cardCmd.AddCommand(searchCmd)
 
-	searchCmd.Flags().Int("id", 0, "Filter by card internal ID")
-	searchCmd.Flags().String("category", "", "Filter by category name")
-	searchCmd.Flags().Int("epic-id", 0, "Filter by epic ID")
-	searchCmd.Flags().Int("number", 0, "Filter by card number")
-	searchCmd.Flags().Int("priority", -1, "Filter by priority")
-	searchCmd.Flags().Int("project-id", 0, "Filter by project ID")
-	searchCmd.Flags().Int("sprint-id", 0, "Filter by sprint ID")
-	searchCmd.Flags().Int("workspace-id", 0, "Filter by workspace ID")
-	searchCmd.Flags().String("assignee-id", "", "Filter by assignee")
-	searchCmd.Flags().String("state", "", "Filter by card state")
-	searchCmd.Flags().String("status", "", "Filter by card status")
+	addCardQueryFlags(searchCmd.Flags())
+	addPaginationFlags(searchCmd.Flags())
+	addCardColumnsFlag(searchCmd.Flags())
 }
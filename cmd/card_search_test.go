@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		args     []string
+		zqlQuery string
+		want     string
+		ok       bool
+	}{
+		{nil, "", "", false},
+		{nil, "status:open", "", true},
+		{[]string{"login"}, "", "login", true},
+		{[]string{"login", "bug"}, "status:open", "login bug", true},
+	}
+
+	for _, test := range tests {
+		got, ok := searchTerms(test.args, test.zqlQuery)
+		if got != test.want || ok != test.ok {
+			t.Errorf("searchTerms(%q, %q) = %q, %v, want %q, %v", test.args, test.zqlQuery, got, ok, test.want, test.ok)
+		}
+	}
+}
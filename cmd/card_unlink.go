@@ -0,0 +1,67 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// cardUnlinkCmd represents the card unlink command
+var cardUnlinkCmd = &cobra.Command{
+	Use:   "unlink <card> <card>",
+	Short: "Remove the relationship between two Zube cards",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		card, err := fetchCardByRef(client, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		otherCard, err := fetchCardByRef(client, args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		relations, err := api.FetchCardRelations(client, card.Id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		relation, ok := lo.Find(relations, func(r api.CardRelation) bool { return r.Card.Id == otherCard.Id })
+		if !ok {
+			log.Fatalf("card #%d is not related to card #%d", card.Number, otherCard.Number)
+		}
+
+		if err := api.DeleteCardRelation(client, card.Id, relation.Id); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Card #%d is no longer related to card #%d", card.Number, otherCard.Number)))
+	},
+}
+
+func init() {
+	cardCmd.AddCommand(cardUnlinkCmd)
+}
@@ -18,51 +18,61 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"os"
 
+	"github.com/logrusorgru/aurora/v4"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
-	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
 	"github.com/platogo/zube-cli/internal/utils"
 )
 
 // cardViewCmd represents the view command
 var cardViewCmd = &cobra.Command{
-	Use:   "view",
+	Use:   "view [card]",
 	Short: "Display the title, status, body and other info about a Zube card.",
+	Long: `Display the title, status, body and other info about a Zube card, followed by the cards it blocks,
+is blocked by or is related to, and its comments.
+Without a card, the card is picked from a list.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		var cardNumber string
+		client := newClient()
 
-		if len(args) > 0 {
-			cardNumber = args[0]
+		card, err := cardFromArgs(client, args)
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		client, _ := zube.NewClient()
-
-		if parentCmd := cmd.Parent().Name(); parentCmd == "card" {
-			cardQueryByNumber := zube.Query{Filter: zube.Filter{Where: map[string]any{"number": cardNumber}}}
-			cards := client.FetchCards(&cardQueryByNumber)
-			if len(cards) == 1 {
-				card := cards[0]
-				comments := client.FetchCardComments(card.Id)
-
-				projectQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": card.ProjectId}}}
-				projects := client.FetchProjects(&projectQueryById)
-				if len(projects) > 0 {
-					project := projects[0]
-					accountQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": project.AccountId}}}
-					accounts := client.FetchAccounts(&accountQueryById)
-
-					utils.PrintCard(&accounts[0], &project, &card)
-					utils.PrintComments(&comments)
-				}
-
-			} else {
-				fmt.Println("Card not found!")
-			}
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&card)
+			return
 		}
+
+		comments := client.FetchCardComments(card.Id)
+
+		account, project, err := fetchCardProject(client, &card)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		utils.PrintCard(&account, &project, &card)
+
+		// Relations are extra information, so the card is still shown without them
+		if relations, err := api.FetchCardRelations(client, card.Id); err != nil {
+			fmt.Fprintln(os.Stderr, aurora.Yellow("Could not load the related cards: "+err.Error()))
+		} else {
+			utils.PrintRelations(relations)
+		}
+
+		utils.PrintComments(&comments)
 	},
 }
 
 func init() {
 	cardCmd.AddCommand(cardViewCmd)
+
+	cardViewCmd.Flags().Bool("raw", false, "Print the card body and comments as Markdown source")
+	viper.BindPFlag("raw", cardViewCmd.Flags().Lookup("raw"))
 }
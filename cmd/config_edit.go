@@ -0,0 +1,88 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configEditCmd represents the config edit command
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in $EDITOR",
+	Long: `Open the config file in $VISUAL or $EDITOR. After saving, the settings are checked, and any
+mistakes can be fixed right away, or the previous config is restored.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := viper.ConfigFileUsed()
+
+		original, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for {
+			if err := utils.EditFile(path); err != nil {
+				log.Fatal(err)
+			}
+
+			err := validateConfigFile(path)
+			if err == nil {
+				break
+			}
+
+			fmt.Println(aurora.Red(err.Error()))
+
+			editAgain := true
+			if err := survey.AskOne(&survey.Confirm{Message: "Edit the config again?", Default: true}, &editAgain); err != nil || !editAgain {
+				if err := os.WriteFile(path, original, 0600); err != nil {
+					log.Fatal(err)
+				}
+				log.Fatal("restored the previous config")
+			}
+		}
+
+		fmt.Println(aurora.Green("Saved " + path))
+	},
+}
+
+func validateConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]any)
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	return config.Validate(settings)
+}
+
+func init() {
+	configCmd.AddCommand(configEditCmd)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// A setting with its current value, as listed by `config list`
+type listedSetting struct {
+	Key         string `json:"key" yaml:"key"`
+	Value       string `json:"value" yaml:"value"`
+	Source      string `json:"source" yaml:"source"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all settings with their values, and where each value comes from",
+	Run: func(cmd *cobra.Command, args []string) {
+		settings := lo.Map(config.Settings, func(setting config.Setting, _ int) listedSetting {
+			value := setting.Value()
+			if setting.Secret && value != "" {
+				value = "(hidden)"
+			}
+			return listedSetting{setting.Key, value, setting.Source(cmd.Flags()), setting.Description}
+		})
+
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&settings)
+			return
+		}
+
+		table := utils.MustNewTable([]utils.TableColumn{
+			{Key: "key", Header: "Key"},
+			{Key: "value", Header: "Value", Flex: true},
+			{Key: "source", Header: "Source"},
+			{Key: "description", Header: "Description", Flex: true},
+		})
+
+		table.Render(lo.Map(settings, func(setting listedSetting, _ int) utils.TableRow {
+			return utils.TableRow{
+				"key":         BrightGreen(setting.Key).String(),
+				"value":       setting.Value,
+				"source":      Gray(14, setting.Source).String(),
+				"description": setting.Description,
+			}
+		}))
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// configProfileCmd represents the config profile command
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles for several Zube accounts",
+	Long: `Manage profiles, each with its own client ID and private key, for working with several Zube accounts.
+
+The profile is picked with the --profile flag, the ZUBE_PROFILE environment variable, or else
+` + "`zube config profile use`" + `. Each profile caches its access token and API responses separately.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("try to use `config profile add` to add a profile")
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configProfileCmd)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// configProfileAddCmd represents the config profile add command
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile, or replace an existing one",
+	Long: `Add a profile with its own client ID and private key. Missing values are prompted for. For example:
+
+  zube config profile add work --client-id some-client-id --private-key-path ~/.ssh/zube_work.pem`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := config.Profile{Name: args[0]}
+		if err := config.ValidateProfileName(profile.Name); err != nil {
+			log.Fatal(err)
+		}
+
+		profile.ClientId, _ = cmd.Flags().GetString("client-id")
+		profile.PrivateKeyPath, _ = cmd.Flags().GetString("private-key-path")
+
+		if profile.ClientId == "" {
+			profile.ClientId = utils.StringPrompt("Enter the Zube Client ID:")
+		}
+		if profile.PrivateKeyPath == "" {
+			profile.PrivateKeyPath = utils.StringPrompt("Enter the path of the private key .pem file:")
+		}
+		if profile.ClientId == "" || profile.PrivateKeyPath == "" {
+			log.Fatal(aurora.Red("Client ID and private key path cannot be blank!"))
+		}
+
+		if err := config.SaveProfile(profile); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Saved profile %s, use it with --profile %s or `zube config profile use %s`", profile.Name, profile.Name, profile.Name)))
+	},
+}
+
+func init() {
+	configProfileCmd.AddCommand(configProfileAddCmd)
+
+	configProfileAddCmd.Flags().String("client-id", "", "Zube Client ID of the account")
+	configProfileAddCmd.Flags().String("private-key-path", "", "Path of the Zube private key .pem file of the account")
+}
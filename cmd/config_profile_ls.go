@@ -0,0 +1,66 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configProfileLsCmd represents the config profile ls command
+var configProfileLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List all profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles := config.Profiles()
+
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&profiles)
+			return
+		}
+
+		active := viper.GetString("profile")
+
+		table := utils.MustNewTable([]utils.TableColumn{
+			{Key: "active", Header: ""},
+			{Key: "name", Header: "Name"},
+			{Key: "client_id", Header: "Client ID", Flex: true},
+			{Key: "private_key_path", Header: "Private key", Flex: true},
+		})
+
+		table.Render(lo.Map(profiles, func(profile config.Profile, _ int) utils.TableRow {
+			marker := ""
+			if profile.Name == active {
+				marker = "*"
+			}
+			return utils.TableRow{
+				"active":           marker,
+				"name":             BrightGreen(profile.Name).String(),
+				"client_id":        profile.ClientId,
+				"private_key_path": profile.PrivateKeyPath,
+			}
+		}))
+	},
+}
+
+func init() {
+	configProfileCmd.AddCommand(configProfileLsCmd)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configProfileRmCmd represents the config profile rm command
+var configProfileRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove a profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.RemoveProfile(args[0]); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green("Removed profile " + args[0]))
+	},
+}
+
+func init() {
+	configProfileCmd.AddCommand(configProfileRmCmd)
+}
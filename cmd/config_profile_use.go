@@ -0,0 +1,54 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configProfileUseCmd represents the config profile use command
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Use a profile by default",
+	Long:  `Use a profile by default. Without a name, the top-level client ID and private key are used again.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		if err := config.UseProfile(name); err != nil {
+			log.Fatal(err)
+		}
+
+		if name == "" {
+			fmt.Println(aurora.Green("No longer using a profile by default"))
+		} else {
+			fmt.Println(aurora.Green("Using profile " + name + " by default"))
+		}
+	},
+}
+
+func init() {
+	configProfileCmd.AddCommand(configProfileUseCmd)
+}
@@ -19,7 +19,7 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -30,10 +30,16 @@ var currentPersonCmd = &cobra.Command{
 	Short: "Show info about your own user",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Construct a client
-		client, _ := zube.NewClient()
+		client := newClient()
 
 		// Call public client API to fetch resource that is needed, then print formatted output
 		person := client.FetchCurrentPerson()
+
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&person)
+			return
+		}
+
 		fmt.Printf("Username: %s\nName: %s\nId: %d\n", person.Username, person.Name, person.Id)
 	},
 }
@@ -17,7 +17,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
-	"github.com/platogo/zube"
+	"log"
+
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -27,16 +28,24 @@ var epicLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "A brief description of your command",
 	Run: func(cmd *cobra.Command, args []string) {
-		if client, err := zube.NewClient(); err == nil {
-			projectId, _ := cmd.Flags().GetInt("project-id")
-			epics := client.FetchEpics(projectId)
-			utils.PrintItems(&epics)
+		client := newClient()
+
+		projectId, err := utils.ResolveProjectId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
 		}
+
+		if projectId == 0 {
+			log.Fatal("either --project or --project-id is required, or set a default with `zube config set default_project`")
+		}
+
+		epics := client.FetchEpics(projectId)
+		utils.PrintItems(&epics)
 	},
 }
 
 func init() {
 	epicCmd.AddCommand(epicLsCmd)
 	epicLsCmd.Flags().Int("project-id", 0, "Project ID")
-	epicLsCmd.MarkFlagRequired("project-id")
+	epicLsCmd.Flags().String("project", "", "Project name")
 }
@@ -0,0 +1,75 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+)
+
+// epicOpenCmd represents the epic open command
+var epicOpenCmd = &cobra.Command{
+	Use:   "open <epic>",
+	Short: "Open a Zube epic in the browser",
+	Long: `Open an epic of a project, given by its title or number, in the browser given by $BROWSER,
+or else the default browser. With --print, the URL is only printed. For example:
+
+  zube epic open "Data export" --project Backend`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		projectId, err := utils.ResolveProjectId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if projectId == 0 {
+			log.Fatal("either --project or --project-id is required, or set a default with `zube config set default_project`")
+		}
+
+		epics := client.FetchEpics(projectId)
+		epic, err := utils.FindByNameOrId("epic", args[0], epics,
+			func(e models.Epic) int { return e.Number },
+			func(e models.Epic) string { return e.Title })
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := fetchProjectById(client, projectId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account, err := fetchProjectAccount(client, &project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		openUrl(cmd, utils.EpicUrl(&account, &project, &epic))
+	},
+}
+
+func init() {
+	epicCmd.AddCommand(epicOpenCmd)
+	addOpenFlags(epicOpenCmd.Flags())
+
+	epicOpenCmd.Flags().Int("project-id", 0, "Project ID")
+	epicOpenCmd.Flags().String("project", "", "Project name")
+}
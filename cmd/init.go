@@ -21,15 +21,18 @@ import (
 	"log"
 
 	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // initCmd represents the config init command
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize the Zube CLI configuration",
+	Long: `Create the config file, readable only by you, and save your Zube Client ID in it.
+An existing config file is kept, with only the Client ID replaced.`,
+	Annotations: map[string]string{noConfigAnnotation: ""},
 	Run: func(cmd *cobra.Command, args []string) {
 		clientId := utils.StringPrompt("Enter your Zube Client ID:")
 
@@ -37,9 +40,20 @@ var initCmd = &cobra.Command{
 			log.Fatal(aurora.Red("Client ID cannot be blank!"))
 		}
 
-		viper.Set("client_id", clientId)
-		viper.WriteConfig()
-		fmt.Println(aurora.Green("Config initialized succesfully!"))
+		path, err := config.Create()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = config.Update(func(settings map[string]any) error {
+			settings["client_id"] = clientId
+			return nil
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green("Config initialized succesfully in " + path))
 		fmt.Println("Don't forget to place your Zube private key at ~/.ssh/zube_api_key.pem")
 		fmt.Println("See https://zube.io/docs/api#generating-a-private-key for more information")
 	},
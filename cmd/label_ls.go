@@ -19,7 +19,6 @@ package cmd
 import (
 	"log"
 
-	"github.com/platogo/zube"
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/platogo/zube/models"
 	"github.com/spf13/cobra"
@@ -31,14 +30,19 @@ var labelLsCmd = &cobra.Command{
 	Short: "List all Zube labels",
 	Long:  `List all registered labels in a project. Will print the color of the label in supported terminals.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		client := newClient()
 
 		var labels []models.Label
 
-		if projectId, err := cmd.Flags().GetInt("project-id"); err == nil && projectId != 0 {
+		projectId, err := utils.ResolveProjectId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if projectId != 0 {
 			labels = client.FetchLabels(projectId)
 		} else {
-			log.Fatal("Project ID is required")
+			log.Fatal("either --project or --project-id is required, or set a default with `zube config set default_project`")
 		}
 
 		utils.PrintItems(&labels)
@@ -49,4 +53,5 @@ func init() {
 	labelCmd.AddCommand(labelLsCmd)
 
 	labelLsCmd.Flags().Int("project-id", 0, "Filter by project ID")
+	labelLsCmd.Flags().String("project", "", "Filter by project name")
 }
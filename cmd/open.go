@@ -0,0 +1,67 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Adds the flags shared by the `open` commands
+func addOpenFlags(flags *pflag.FlagSet) {
+	flags.Bool("print", false, "Only print the URL instead of opening it")
+}
+
+// Opens a URL in the browser or, with --print, prints it
+func openUrl(cmd *cobra.Command, url string) {
+	if print, _ := cmd.Flags().GetBool("print"); print {
+		fmt.Println(url)
+		return
+	}
+
+	if err := utils.OpenBrowser(url); err != nil {
+		log.Fatalf("failed to open %s: %s", url, err)
+	}
+}
+
+// Fetches a project by its ID
+func fetchProjectById(client *zube.Client, id int) (models.Project, error) {
+	projectQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": id}}}
+	projects := client.FetchProjects(&projectQueryById)
+	if len(projects) == 0 {
+		return models.Project{}, fmt.Errorf("project %d not found", id)
+	}
+
+	return projects[0], nil
+}
+
+// Fetches a workspace by its ID
+func fetchWorkspaceById(client *zube.Client, id int) (models.Workspace, error) {
+	workspaceQueryById := zube.Query{Filter: zube.Filter{Where: map[string]any{"id": id}}}
+	workspaces := client.FetchWorkspaces(&workspaceQueryById)
+	if len(workspaces) == 0 {
+		return models.Workspace{}, fmt.Errorf("workspace %d not found", id)
+	}
+
+	return workspaces[0], nil
+}
@@ -28,7 +28,7 @@ var projectLsCmd = &cobra.Command{
 	Short: "List all Zube projects",
 	Long:  `You can use this command to list all projects accessible to your user.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		client := newClient()
 
 		projects := client.FetchProjects(&zube.Query{})
 		utils.PrintItems(&projects)
@@ -0,0 +1,58 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+)
+
+// projectOpenCmd represents the project open command
+var projectOpenCmd = &cobra.Command{
+	Use:   "open <project>",
+	Short: "Open a Zube project in the browser",
+	Long: `Open a Zube project, given by its name or ID, in the browser given by $BROWSER, or else the default browser.
+With --print, the URL is only printed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		projects := client.FetchProjects(&zube.Query{})
+		project, err := utils.FindByNameOrId("project", args[0], projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account, err := fetchProjectAccount(client, &project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		openUrl(cmd, utils.ProjectUrl(&account, &project))
+	},
+}
+
+func init() {
+	projectCmd.AddCommand(projectOpenCmd)
+	addOpenFlags(projectOpenCmd.Flags())
+}
@@ -4,11 +4,18 @@ Copyright © 2022 Daniils Petrovs <daniils@platogo.com>
 package cmd
 
 import (
-	"fmt"
+	"errors"
+	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/platogo/cache"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/auth"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,6 +28,16 @@ var rootCmd = &cobra.Command{
 	Short:   "A Command Line utility for interacting with Zube.io",
 	Long:    `Zube-CLI is a CLI tool built in Go that allows you to manage Zube cards, projects and other resources from the terminal.`,
 	Version: Version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		utils.ConfigureColors(viper.GetString("color"))
+
+		bindCardColumnsFlag(cmd)
+		return utils.ValidateOutputOptions()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -32,22 +49,87 @@ func Execute() {
 	}
 }
 
-func init() {
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.zube-cli.yaml)")
-	defaultConfigPath := filepath.Join("$HOME", "config", "zube")
-	xdgConfigPath := filepath.Join("$XDG_CONFIG_HOME", "zube")
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(defaultConfigPath)
-	viper.AddConfigPath(xdgConfigPath)
+// Commands annotated with this run without a config file, e.g. `config init`
+const noConfigAnnotation = "no-config"
 
-	err := viper.ReadInConfig()
+// Reads the config file given with --config or ZUBE_CONFIG, or else found in the config directories,
+// and any per-repository config file on top of it.
+// Every command but `config init`, help and shell completions requires one, unless the client ID is set
+// through the environment.
+func loadConfig(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = os.Getenv("ZUBE_CONFIG")
+	}
+	if path != "" {
+		viper.SetConfigFile(path)
+	}
 
-	if err != nil { // Handle errors reading the config file
-		panic(fmt.Errorf("Fatal error config file: %w \n", err))
+	err := config.Load()
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return err
+	}
+	if localErr := config.LoadLocal(); localErr != nil {
+		return localErr
+	}
+	if err == nil || viper.IsSet("client_id") {
+		return nil
 	}
 
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[noConfigAnnotation]; ok || c.Name() == "help" || c.Name() == "completion" || c.Name() == cobra.ShellCompRequestCmd {
+			return nil
+		}
+	}
+	return err
+}
+
+// Creates a Zube client for the active profile or, without one, for the top-level client ID and private key
+func newClient() *zube.Client {
+	profile, ok, err := config.Credentials(rootCmd.PersistentFlags())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var client *zube.Client
+	if ok {
+		client, err = auth.NewClient(profile)
+	} else {
+		client, err = zube.NewClient()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if ttl := viper.GetDuration("cache_ttl"); ttl > 0 {
+		if cacheDir, err := os.UserCacheDir(); err == nil {
+			utils.PruneCache(filepath.Join(cacheDir, cache.CacheDirName), ttl, time.Now())
+		}
+	}
+
+	return client
+}
+
+func init() {
+	config.Init()
+
 	cache.Init()
+
+	rootCmd.PersistentFlags().String("config", "", "Config file (default is $XDG_CONFIG_HOME/zube/config.yml, or $ZUBE_CONFIG)")
+	rootCmd.PersistentFlags().String("profile", "", "Config profile to use, see `zube config profile ls`")
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+
+	rootCmd.PersistentFlags().StringP("output", "o", utils.OutputTable, "Output format, one of: "+strings.Join(utils.OutputFormats, ", "))
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	rootCmd.PersistentFlags().String("template", "", "Format the output with a Go template, e.g. '{{.Number}} {{.Title}}'")
+	viper.BindPFlag("template", rootCmd.PersistentFlags().Lookup("template"))
+	rootCmd.PersistentFlags().String("jq", "", "Filter the JSON output with a jq expression, e.g. '.[] | .number'")
+	viper.BindPFlag("jq", rootCmd.PersistentFlags().Lookup("jq"))
+	rootCmd.PersistentFlags().Bool("wide", false, "Show all table columns, without truncating them to the terminal width")
+	viper.BindPFlag("wide", rootCmd.PersistentFlags().Lookup("wide"))
+	rootCmd.PersistentFlags().Bool("no-headers", false, "Print tables without their header")
+	viper.BindPFlag("no-headers", rootCmd.PersistentFlags().Lookup("no-headers"))
+
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
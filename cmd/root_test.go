@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadConfigWithoutFile(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	t.Setenv("ZUBE_CONFIG", filepath.Join(t.TempDir(), "config.yml"))
+	t.Setenv("ZUBE_CLIENT_ID", "")
+
+	rootCmd.InitDefaultHelpCmd()
+	help, _, err := rootCmd.Find([]string{"help"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadConfig(help); err != nil {
+		t.Errorf("expected help to run without a config file, got %v", err)
+	}
+	if err := loadConfig(cardLsCmd); err == nil {
+		t.Error("expected card ls to require a config file")
+	}
+}
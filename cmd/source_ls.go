@@ -17,7 +17,6 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
-	"github.com/platogo/zube"
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -27,10 +26,10 @@ var sourceLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List all sources",
 	Run: func(cmd *cobra.Command, args []string) {
-		if client, err := zube.NewClient(); err == nil {
-			sources := client.FetchSources()
-			utils.PrintItems(&sources)
-		}
+		client := newClient()
+
+		sources := client.FetchSources()
+		utils.PrintItems(&sources)
 	},
 }
 
@@ -19,7 +19,6 @@ package cmd
 import (
 	"log"
 
-	"github.com/platogo/zube"
 	"github.com/platogo/zube-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -29,13 +28,18 @@ var sprintLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List sprints in a workspace",
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		client := newClient()
 
-		if workspaceId, err := cmd.Flags().GetInt("workspace-id"); err == nil && workspaceId != 0 {
+		workspaceId, err := utils.ResolveWorkspaceId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if workspaceId != 0 {
 			sprints := client.FetchSprints(workspaceId)
 			utils.PrintItems(&sprints)
 		} else {
-			log.Fatal("workspace-id is required")
+			log.Fatal("either --workspace or --workspace-id is required, or set a default with `zube config set default_workspace`")
 		}
 	},
 }
@@ -44,4 +48,5 @@ func init() {
 	sprintCmd.AddCommand(sprintLsCmd)
 
 	sprintLsCmd.Flags().Int("workspace-id", 0, "Filter by workspace ID")
+	sprintLsCmd.Flags().String("workspace", "", "Filter by workspace name")
 }
@@ -0,0 +1,82 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// sprintOpenCmd represents the sprint open command
+var sprintOpenCmd = &cobra.Command{
+	Use:   "open [sprint]",
+	Short: "Open a Zube sprint in the browser",
+	Long: `Open a sprint of a workspace, given by its title or ID, in the browser given by $BROWSER,
+or else the default browser. Without a sprint, the open sprint of the workspace is opened.
+With --print, the URL is only printed. For example:
+
+  zube sprint open --workspace Development`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		workspaceId, err := utils.ResolveWorkspaceId(client, cmd.Flags())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if workspaceId == 0 {
+			log.Fatal("either --workspace or --workspace-id is required, or set a default with `zube config set default_workspace`")
+		}
+
+		sprintName := "@current-sprint"
+		if len(args) > 0 {
+			sprintName = args[0]
+		}
+
+		sprint, err := utils.FindSprint(client.FetchSprints(workspaceId), sprintName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		workspace, err := fetchWorkspaceById(client, workspaceId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := fetchProjectById(client, workspace.ProjectId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account, err := fetchProjectAccount(client, &project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		openUrl(cmd, utils.SprintUrl(&account, &project, &workspace, &sprint))
+	},
+}
+
+func init() {
+	sprintCmd.AddCommand(sprintOpenCmd)
+	addOpenFlags(sprintOpenCmd.Flags())
+
+	sprintOpenCmd.Flags().Int("workspace-id", 0, "Workspace ID")
+	sprintOpenCmd.Flags().String("workspace", "", "Workspace name")
+}
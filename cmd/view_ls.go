@@ -0,0 +1,53 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"github.com/kballard/go-shellquote"
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// viewLsCmd represents the view ls command
+var viewLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List all saved views",
+	Run: func(cmd *cobra.Command, args []string) {
+		views := config.Views()
+
+		if utils.IsStructuredOutput() {
+			utils.PrintStructured(&views)
+			return
+		}
+
+		table := utils.MustNewTable([]utils.TableColumn{
+			{Key: "name", Header: "Name"},
+			{Key: "filters", Header: "Filters", Flex: true},
+		})
+
+		table.Render(lo.Map(views, func(view config.View, _ int) utils.TableRow {
+			return utils.TableRow{"name": BrightGreen(view.Name).String(), "filters": shellquote.Join(view.Args...)}
+		}))
+	},
+}
+
+func init() {
+	viewCmd.AddCommand(viewLsCmd)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// viewRunCmd represents the view run command
+var viewRunCmd = &cobra.Command{
+	Use:   "run <name> [card ls flags...]",
+	Short: "List the cards of a saved view",
+	Long: `Run a saved view as ` + "`card ls`" + `. Any additional flags are appended to the saved ones,
+e.g. ` + "`zube view run mine --output json`" + `.`,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+			cmd.Help()
+			return
+		}
+
+		view, err := config.GetView(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := parseViewFlags(view, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+		cardLsCmd.Run(cardLsCmd, cardLsCmd.Flags().Args())
+	},
+}
+
+// Parses the flags of a saved view, followed by any additional ones, as flags of card ls.
+// The root command's hooks only ran for view run itself, so the card ls flags are bound here.
+func parseViewFlags(view config.View, args []string) error {
+	if err := cardLsCmd.ParseFlags(append(view.Args, args...)); err != nil {
+		return err
+	}
+	bindCardColumnsFlag(cardLsCmd)
+
+	return utils.ValidateOutputOptions()
+}
+
+func init() {
+	viewCmd.AddCommand(viewRunCmd)
+}
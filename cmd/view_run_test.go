@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func TestParseViewFlags(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	view := config.View{Name: "mine", Args: []string{"--assignee", "@me", "--columns", "number,title,labels"}}
+	if err := parseViewFlags(view, []string{"--status", "open"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if columns := viper.GetStringSlice("columns"); !reflect.DeepEqual(columns, []string{"number", "title", "labels"}) {
+		t.Errorf("expected the columns of the view, got %v", columns)
+	}
+
+	// The labels column needs whole cards
+	if query := utils.NewQueryFromFlags(cardLsCmd.Flags()); query.Filter.Select != nil {
+		t.Errorf("expected whole cards, got %v", query.Filter.Select)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// viewSaveCmd represents the view save command
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name> [card ls flags...]",
+	Short: "Save card ls filters as a named view",
+	Long: `Save any ` + "`card ls`" + ` flags under a name. For example:
+
+  zube view save mine --workspace Development --sprint @current-sprint --assignee @me
+  zube view save bugs --project Backend -q 'label:bug -status:done'`,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+			cmd.Help()
+			return
+		}
+
+		name, filters := args[0], args[1:]
+
+		// Catch typos in the filters now, rather than when running the view
+		if err := cardLsCmd.ParseFlags(filters); err != nil {
+			log.Fatal(err)
+		}
+
+		if positional := cardLsCmd.Flags().Args(); len(positional) > 0 {
+			log.Fatalf("unexpected arguments %v, views can only contain flags", positional)
+		}
+
+		if err := config.SaveView(config.View{Name: name, Args: filters}); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(aurora.Green(fmt.Sprintf("Saved view %s, run it with `zube view run %s`", name, name)))
+	},
+}
+
+func init() {
+	viewCmd.AddCommand(viewSaveCmd)
+}
@@ -27,7 +27,7 @@ var workspaceLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List workspaces",
 	Run: func(cmd *cobra.Command, args []string) {
-		client, _ := zube.NewClient()
+		client := newClient()
 		workspaces := client.FetchWorkspaces(&zube.Query{})
 		utils.PrintItems(&workspaces)
 	},
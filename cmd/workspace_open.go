@@ -0,0 +1,63 @@
+/*
+Copyright © 2023 Daniils Petrovs <daniils@platogo.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+import (
+	"log"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/utils"
+	"github.com/platogo/zube/models"
+	"github.com/spf13/cobra"
+)
+
+// workspaceOpenCmd represents the workspace open command
+var workspaceOpenCmd = &cobra.Command{
+	Use:   "open <workspace>",
+	Short: "Open the board of a Zube workspace in the browser",
+	Long: `Open the board of a Zube workspace, given by its name or ID, in the browser given by $BROWSER,
+or else the default browser. With --print, the URL is only printed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient()
+
+		workspaces := client.FetchWorkspaces(&zube.Query{})
+		workspace, err := utils.FindByNameOrId("workspace", args[0], workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := fetchProjectById(client, workspace.ProjectId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account, err := fetchProjectAccount(client, &project)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		openUrl(cmd, utils.WorkspaceUrl(&account, &project, &workspace))
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceOpenCmd)
+	addOpenFlags(workspaceOpenCmd.Flags())
+}
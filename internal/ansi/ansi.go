@@ -0,0 +1,20 @@
+// Package ansi measures styled terminal text, ignoring its color escape sequences.
+package ansi
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// Matches a color escape sequence
+var Pattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Removes color escape sequences
+func Strip(s string) string {
+	return Pattern.ReplaceAllString(s, "")
+}
+
+// The width of a string in the terminal, without color escape sequences
+func Width(s string) int {
+	return utf8.RuneCountInString(Strip(s))
+}
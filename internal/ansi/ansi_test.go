@@ -0,0 +1,14 @@
+package ansi
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	styled := "\x1b[1;31mbug\x1b[0m fix"
+
+	if got := Strip(styled); got != "bug fix" {
+		t.Errorf("got %q", got)
+	}
+	if got := Width(styled); got != 7 {
+		t.Errorf("expected a width of 7, got %d", got)
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/platogo/zube"
+	"github.com/spf13/viper"
+)
+
+// Zube API endpoints that are not (yet) part of the `zube` client library
+
+// Base URL of the Zube API, only changed in tests
+var ZubeHost = "https://zube.io"
+
+// Performs an authenticated request against the Zube API and decodes the JSON response into `out`, if given
+func Request(client *zube.Client, method, path string, params url.Values, body any, out any) error {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	reqUrl := ZubeHost + path
+	if len(params) > 0 {
+		reqUrl += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequest(method, reqUrl, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+client.AccessToken)
+	req.Header.Set("X-Client-ID", viper.GetString("client_id"))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s failed: %s %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+
+	return nil
+}
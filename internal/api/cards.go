@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+)
+
+// Fetches a single card by its internal ID
+func FetchCard(client *zube.Client, cardId int) (models.Card, error) {
+	var card models.Card
+	err := Request(client, http.MethodGet, fmt.Sprintf("/api/cards/%d", cardId), nil, nil, &card)
+	return card, err
+}
+
+// Updates only the given card attributes, e.g. `{"title": "New title"}`, and returns the updated card
+func UpdateCard(client *zube.Client, cardId int, changes map[string]any) (models.Card, error) {
+	var card models.Card
+	err := Request(client, http.MethodPut, fmt.Sprintf("/api/cards/%d", cardId), nil, changes, &card)
+	return card, err
+}
+
+// Where a card is moved to. `Type` is either "category" or "project" (triage)
+type Destination struct {
+	Position    int    `json:"position"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	WorkspaceId int    `json:"workspace_id,omitempty"`
+}
+
+// Moves a card to the given destination and returns the moved card
+func MoveCard(client *zube.Client, cardId int, destination Destination) (models.Card, error) {
+	var card models.Card
+	body := map[string]any{"destination": destination}
+	err := Request(client, http.MethodPut, fmt.Sprintf("/api/cards/%d/move", cardId), nil, body, &card)
+	return card, err
+}
+
+// Counts the cards matching the query without fetching them
+func CountCards(client *zube.Client, query *zube.Query) (int, error) {
+	var page Page[models.Card]
+	params := QueryValues(query)
+	params.Set("per_page", "1")
+	params.Del("select[]")
+	params.Add("select[]", "id")
+	err := Request(client, http.MethodGet, "/api/cards", params, nil, &page)
+	return page.Pagination.Total, err
+}
+
+// Archives a card
+func ArchiveCard(client *zube.Client, cardId int) (models.Card, error) {
+	return cardAction(client, cardId, "archive")
+}
+
+// Closes a card
+func CloseCard(client *zube.Client, cardId int) (models.Card, error) {
+	return cardAction(client, cardId, "close")
+}
+
+// Reopens a closed or archived card
+func ReopenCard(client *zube.Client, cardId int) (models.Card, error) {
+	return cardAction(client, cardId, "reopen")
+}
+
+func cardAction(client *zube.Client, cardId int, action string) (models.Card, error) {
+	var card models.Card
+	err := Request(client, http.MethodPut, fmt.Sprintf("/api/cards/%d/%s", cardId, action), nil, nil, &card)
+	return card, err
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+)
+
+// A workspace category, i.e. a column on the Zube board
+type Category struct {
+	Id          int    `json:"id"`
+	WorkspaceId int    `json:"workspace_id"`
+	Name        string `json:"name"`
+	Position    int    `json:"position"`
+}
+
+// Fetches all categories of a workspace
+func FetchCategories(client *zube.Client, workspaceId int) ([]Category, error) {
+	var page Page[Category]
+	params := url.Values{"per_page": {"100"}}
+	err := Request(client, http.MethodGet, fmt.Sprintf("/api/workspaces/%d/categories", workspaceId), params, nil, &page)
+	return page.Data, err
+}
+
+// A card with the name and position of the workspace category it is in
+type CategoryCard struct {
+	models.Card
+	CategoryName string `json:"category_name"`
+	Position     int    `json:"position"`
+}
+
+// Fetches every card of a workspace, with its category
+func FetchWorkspaceCards(client *zube.Client, workspaceId int) ([]CategoryCard, error) {
+	query := zube.Query{Filter: zube.Filter{Where: map[string]any{"workspace_id": workspaceId}}}
+
+	var cards []CategoryCard
+	err := EachPage(client, "/api/cards", &query, PageOptions{All: true, PerPage: 100, Parallel: 4}, func(page []CategoryCard) error {
+		cards = append(cards, page...)
+		return nil
+	})
+
+	return cards, err
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+)
+
+// Adds a new comment to a card
+func CreateComment(client *zube.Client, cardId int, body string) (models.Comment, error) {
+	var comment models.Comment
+	path := fmt.Sprintf("/api/cards/%d/comments", cardId)
+	err := Request(client, http.MethodPost, path, nil, map[string]any{"body": body}, &comment)
+	return comment, err
+}
+
+// Replaces the body of an existing comment
+func UpdateComment(client *zube.Client, cardId, commentId int, body string) (models.Comment, error) {
+	var comment models.Comment
+	path := fmt.Sprintf("/api/cards/%d/comments/%d", cardId, commentId)
+	err := Request(client, http.MethodPut, path, nil, map[string]any{"body": body}, &comment)
+	return comment, err
+}
+
+// Deletes a comment
+func DeleteComment(client *zube.Client, cardId, commentId int) error {
+	path := fmt.Sprintf("/api/cards/%d/comments/%d", cardId, commentId)
+	return Request(client, http.MethodDelete, path, nil, nil, nil)
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/platogo/zube"
+)
+
+// Pagination metadata of a paginated Zube API response
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+	Total      int `json:"total"`
+}
+
+// A single page of a paginated Zube API response
+type Page[T any] struct {
+	Pagination Pagination `json:"pagination"`
+	Data       []T        `json:"data"`
+}
+
+// Encodes a zube `Query` into Zube API URL parameters, e.g. `where[status]=open&order[by]=number`
+func QueryValues(query *zube.Query) url.Values {
+	params := url.Values{}
+
+	if query == nil {
+		return params
+	}
+
+	keys := make([]string, 0, len(query.Filter.Where))
+	for key := range query.Filter.Where {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch value := query.Filter.Where[key].(type) {
+		case []string:
+			for _, v := range value {
+				params.Add(fmt.Sprintf("where[%s][]", key), v)
+			}
+		case []int:
+			for _, v := range value {
+				params.Add(fmt.Sprintf("where[%s][]", key), fmt.Sprint(v))
+			}
+		default:
+			params.Add(fmt.Sprintf("where[%s]", key), fmt.Sprint(value))
+		}
+	}
+
+	for _, column := range query.Filter.Select {
+		params.Add("select[]", column)
+	}
+
+	if query.Order.By != "" {
+		params.Set("order[by]", query.Order.By)
+	}
+
+	if query.Direction != "" {
+		params.Set("order[direction]", query.Direction)
+	}
+
+	if query.Search != "" {
+		params.Set("search", query.Search)
+	}
+
+	return params
+}
+
+// Options for walking the pages of a paginated endpoint
+type PageOptions struct {
+	Page     int // first page to fetch, starting at 1
+	PerPage  int // items per page, the Zube API default if zero
+	Limit    int // maximum number of items, unlimited if zero
+	All      bool
+	Parallel int // number of pages fetched concurrently with `All`
+}
+
+// Returned by the callback of `EachPage` to stop walking the pages early, e.g. once enough items were found
+var ErrStopPaging = errors.New("stop paging")
+
+type pageResult[T any] struct {
+	page Page[T]
+	err  error
+}
+
+// Fetches a single page of a paginated endpoint
+func FetchPage[T any](client *zube.Client, path string, query *zube.Query, page, perPage int) (Page[T], error) {
+	var result Page[T]
+
+	params := QueryValues(query)
+	params.Set("page", fmt.Sprint(page))
+	if perPage > 0 {
+		params.Set("per_page", fmt.Sprint(perPage))
+	}
+
+	err := Request(client, http.MethodGet, path, params, nil, &result)
+	return result, err
+}
+
+// Walks the pages of a paginated endpoint and calls `each` with the items of every page, in order.
+// Without `All` or a `Limit`, only a single page is fetched. With `All`, the pages after the first one
+// are fetched concurrently, so `each` can already process the first pages while the others load.
+// `each` can return `ErrStopPaging` to stop without an error.
+func EachPage[T any](client *zube.Client, path string, query *zube.Query, opts PageOptions, each func(items []T) error) error {
+	if err := eachPage(client, path, query, opts, each); !errors.Is(err, ErrStopPaging) {
+		return err
+	}
+	return nil
+}
+
+func eachPage[T any](client *zube.Client, path string, query *zube.Query, opts PageOptions, each func(items []T) error) error {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+
+	remaining := opts.Limit
+	emit := func(items []T) error {
+		if opts.Limit > 0 {
+			if len(items) > remaining {
+				items = items[:remaining]
+			}
+			remaining -= len(items)
+		}
+		return each(items)
+	}
+
+	first, err := FetchPage[T](client, path, query, page, opts.PerPage)
+	if err != nil {
+		return err
+	}
+	if err := emit(first.Data); err != nil {
+		return err
+	}
+
+	lastPage := first.Pagination.TotalPages
+	done := func(p int) bool {
+		return p > lastPage || (opts.Limit > 0 && remaining <= 0) || (!opts.All && opts.Limit == 0)
+	}
+
+	if opts.All && opts.Parallel > 1 && opts.Limit == 0 {
+		return eachPageParallel(client, path, query, opts, page+1, lastPage, emit)
+	}
+
+	for p := page + 1; !done(p); p++ {
+		next, err := FetchPage[T](client, path, query, p, opts.PerPage)
+		if err != nil {
+			return err
+		}
+		if len(next.Data) == 0 {
+			break
+		}
+		if err := emit(next.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eachPageParallel[T any](client *zube.Client, path string, query *zube.Query, opts PageOptions, from, to int, emit func([]T) error) error {
+	if from > to {
+		return nil
+	}
+
+	results := make([]chan pageResult[T], to-from+1)
+	semaphore := make(chan struct{}, opts.Parallel)
+
+	for i := range results {
+		results[i] = make(chan pageResult[T], 1)
+
+		go func(i int) {
+			semaphore <- struct{}{}
+			page, err := FetchPage[T](client, path, query, from+i, opts.PerPage)
+			<-semaphore
+			results[i] <- pageResult[T]{page, err}
+		}(i)
+	}
+
+	// Results are emitted in page order, no matter in which order the requests finish
+	for _, result := range results {
+		r := <-result
+		if r.err != nil {
+			return r.err
+		}
+		if err := emit(r.page.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
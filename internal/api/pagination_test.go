@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/platogo/zube"
+)
+
+// Serves `total` numbered items in pages of `perPage`
+func pagedServer(t *testing.T, total, perPage int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		totalPages := (total + perPage - 1) / perPage
+
+		result := Page[int]{Pagination: Pagination{Page: page, PerPage: perPage, TotalPages: totalPages, Total: total}}
+		for i := (page-1)*perPage + 1; i <= total && i <= page*perPage; i++ {
+			result.Data = append(result.Data, i)
+		}
+
+		json.NewEncoder(w).Encode(result)
+	}))
+
+	host := ZubeHost
+	ZubeHost = server.URL
+	t.Cleanup(func() {
+		ZubeHost = host
+		server.Close()
+	})
+
+	return server
+}
+
+func collectPages(t *testing.T, opts PageOptions) []int {
+	t.Helper()
+
+	var items []int
+	err := EachPage(&zube.Client{}, "/api/cards", nil, opts, func(page []int) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return items
+}
+
+func TestEachPage(t *testing.T) {
+	pagedServer(t, 25, 10)
+
+	tests := []struct {
+		name string
+		opts PageOptions
+		want int
+		last int
+	}{
+		{"first page only", PageOptions{}, 10, 10},
+		{"given page", PageOptions{Page: 3}, 5, 25},
+		{"limit", PageOptions{Limit: 15}, 15, 15},
+		{"all", PageOptions{All: true}, 25, 25},
+		{"all in parallel", PageOptions{All: true, Parallel: 3}, 25, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := collectPages(t, tt.opts)
+
+			if len(items) != tt.want || items[len(items)-1] != tt.last {
+				t.Fatalf("got %v, want %d items ending in %d", items, tt.want, tt.last)
+			}
+
+			for i := 1; i < len(items); i++ {
+				if items[i] != items[i-1]+1 {
+					t.Fatalf("items out of order: %v", items)
+				}
+			}
+		})
+	}
+}
+
+func TestEachPageStop(t *testing.T) {
+	pagedServer(t, 25, 10)
+
+	for _, parallel := range []int{0, 3} {
+		pages := 0
+		err := EachPage(&zube.Client{}, "/api/cards", nil, PageOptions{All: true, Parallel: parallel}, func(page []int) error {
+			if pages++; pages == 2 {
+				return ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pages != 2 {
+			t.Errorf("expected to stop after 2 pages with parallel %d, got %d", parallel, pages)
+		}
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	query := zube.Query{Search: "login"}
+	query.Filter.Where = map[string]any{"status": "queued", "label_ids": []int{1, 2}}
+	query.Filter.Select = []string{"number"}
+	query.Order.By = "number"
+	query.Direction = "asc"
+
+	got := QueryValues(&query).Encode()
+	want := "order%5Bby%5D=number&order%5Bdirection%5D=asc&search=login&select%5B%5D=number&where%5Blabel_ids%5D%5B%5D=1&where%5Blabel_ids%5D%5B%5D=2&where%5Bstatus%5D=queued"
+
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
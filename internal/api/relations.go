@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+)
+
+// Kinds of relationships between cards, as seen from the card they are fetched for
+const (
+	RelationBlocks    = "blocks"
+	RelationBlockedBy = "blocked_by"
+	RelationRelated   = "related"
+)
+
+// A relationship of a card to another card
+type CardRelation struct {
+	Id   int         `json:"id"`
+	Type string      `json:"type"`
+	Card models.Card `json:"related_card"`
+}
+
+// Fetches the relationships of a card to other cards
+func FetchCardRelations(client *zube.Client, cardId int) ([]CardRelation, error) {
+	var page Page[CardRelation]
+	params := url.Values{"per_page": {"100"}}
+	err := Request(client, http.MethodGet, fmt.Sprintf("/api/cards/%d/relationships", cardId), params, nil, &page)
+	return page.Data, err
+}
+
+// Relates a card to another card, e.g. `RelationBlocks` if the card blocks the other one
+func CreateCardRelation(client *zube.Client, cardId, relatedCardId int, relationType string) (CardRelation, error) {
+	var relation CardRelation
+	body := map[string]any{"related_card_id": relatedCardId, "type": relationType}
+	err := Request(client, http.MethodPost, fmt.Sprintf("/api/cards/%d/relationships", cardId), nil, body, &relation)
+	return relation, err
+}
+
+// Removes a relationship between two cards
+func DeleteCardRelation(client *zube.Client, cardId, relationId int) error {
+	return Request(client, http.MethodDelete, fmt.Sprintf("/api/cards/%d/relationships/%d", cardId, relationId), nil, nil, nil)
+}
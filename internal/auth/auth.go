@@ -0,0 +1,141 @@
+// Package auth authenticates Zube clients for config profiles, each with its own access token cache
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/platogo/cache"
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/config"
+	"github.com/spf13/viper"
+)
+
+// Access tokens are refreshed this long before they expire, so they don't expire during a command
+const expiryMargin = time.Minute
+
+// Creates a client for a profile. Its access token is cached until it expires, and its API responses
+// are cached separately from other profiles. Without a writable cache directory, nothing is cached.
+func NewClient(profile config.Profile) (*zube.Client, error) {
+	if profile.ClientId == "" {
+		return nil, fmt.Errorf("profile %s has no client_id", profile.Name)
+	}
+
+	// Requests read the client ID from the config
+	viper.Set("client_id", profile.ClientId)
+	key := cacheKey(profile)
+	cache.InitWithName("zube-" + key)
+
+	client := zube.NewClientWithId(profile.ClientId)
+
+	var tokenPath string
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		tokenPath = filepath.Join(cacheDir, "zube-tokens", key)
+	}
+
+	if token, err := os.ReadFile(tokenPath); tokenPath != "" && err == nil && !isExpired(string(token), time.Now()) {
+		client.AccessToken = string(token)
+		return client, nil
+	}
+
+	var privateKey *rsa.PrivateKey
+	var err error
+	if profile.PrivateKey != "" {
+		privateKey, err = parsePrivateKey([]byte(profile.PrivateKey))
+	} else {
+		privateKey, err = readPrivateKey(profile.PrivateKeyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := client.RefreshAccessToken(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	client.AccessToken = token
+
+	if tokenPath != "" && os.MkdirAll(filepath.Dir(tokenPath), 0700) == nil {
+		os.WriteFile(tokenPath, []byte(token), 0600)
+	}
+	return client, nil
+}
+
+// Names the caches of a profile by its name and client ID, so a profile never gets the token or responses
+// of another account, even after its client ID changed. The hash is also safe to use as a file name.
+func cacheKey(profile config.Profile) string {
+	sum := sha256.Sum256([]byte(profile.Name + "\x00" + profile.ClientId))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Reads a PEM encoded RSA private key, e.g. `~/.ssh/zube_api_key.pem`
+func readPrivateKey(path string) (*rsa.PrivateKey, error) {
+	if path == "" {
+		return nil, errors.New("no private_key_path set")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePrivateKey(data)
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// Whether a JWT access token expires within the expiry margin. Tokens without a readable expiry count as expired.
+func isExpired(token string, now time.Time) bool {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return true
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return true
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return true
+	}
+
+	return now.Add(expiryMargin).After(time.Unix(claims.Exp, 0))
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/platogo/zube-cli/internal/config"
+)
+
+func token(exp int64) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp)))
+	return "header." + payload + ".signature"
+}
+
+func TestCacheKey(t *testing.T) {
+	work := config.Profile{Name: "work", ClientId: "work-id"}
+
+	keys := map[string]bool{
+		cacheKey(work): true,
+		cacheKey(config.Profile{Name: "work", ClientId: "other-id"}):   true,
+		cacheKey(config.Profile{Name: "default", ClientId: "work-id"}): true,
+		cacheKey(config.Profile{Name: "../x", ClientId: "work-id"}):    true,
+	}
+	if len(keys) != 4 {
+		t.Errorf("expected distinct cache keys for different names and client IDs, got %v", keys)
+	}
+
+	for key := range keys {
+		if strings.ContainsAny(key, `/\.`) {
+			t.Errorf("cache key %q is not a plain file name", key)
+		}
+	}
+
+	if cacheKey(work) != cacheKey(work) {
+		t.Error("expected the same cache key for the same profile")
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{token(now.Add(time.Hour).Unix()), false},
+		{token(now.Add(30 * time.Second).Unix()), true},
+		{token(now.Add(-time.Hour).Unix()), true},
+		{token(0), true},
+		{"not-a-token", true},
+	}
+
+	for _, test := range tests {
+		if got := isExpired(test.token, now); got != test.want {
+			t.Errorf("isExpired(%q) = %v, want %v", test.token, got, test.want)
+		}
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if parsed, err := parsePrivateKey(pkcs1); err != nil || !parsed.Equal(key) {
+		t.Errorf("PKCS #1 key not parsed: %v", err)
+	}
+
+	pkcs8Bytes, _ := x509.MarshalPKCS8PrivateKey(key)
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+	if parsed, err := parsePrivateKey(pkcs8); err != nil || !parsed.Equal(key) {
+		t.Errorf("PKCS #8 key not parsed: %v", err)
+	}
+
+	if _, err := parsePrivateKey([]byte("garbage")); err == nil {
+		t.Error("expected an error for a key that is not PEM encoded")
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Returned by `Load` when there is no config file yet
+var ErrNotFound = errors.New("no config file found, run `zube config init` to create one")
+
+// The directory new config files are created in, e.g. `~/.config/zube`
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zube"), nil
+}
+
+// Sets up where the config file is searched: in `Dir`, and for older setups in `~/config/zube`.
+// Every setting can also be given as an environment variable, see `Setting.Env`.
+func Init() {
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	if dir, err := Dir(); err == nil {
+		viper.AddConfigPath(dir)
+	}
+	viper.AddConfigPath(filepath.Join("$HOME", "config", "zube"))
+	viper.AddConfigPath(filepath.Join("$XDG_CONFIG_HOME", "zube"))
+
+	for _, setting := range Settings {
+		viper.BindEnv(setting.Key, setting.Env())
+	}
+}
+
+// Reads the config file, returning `ErrNotFound` if there is none
+func Load() error {
+	err := viper.ReadInConfig()
+
+	var notFound viper.ConfigFileNotFoundError
+	if errors.As(err, &notFound) || errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Creates an empty config file, readable only by the user, unless a config file exists already.
+// The file is created in `Dir`, unless another path was set with `viper.SetConfigFile`. Returns the path of the config file.
+func Create() (string, error) {
+	if err := Load(); err == nil {
+		return viper.ConfigFileUsed(), nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		dir, err := Dir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(dir, "config.yml")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return "", err
+	}
+	if file != nil {
+		file.Close()
+	}
+
+	viper.SetConfigFile(path)
+	return path, viper.ReadInConfig()
+}
+
+// Rewrites the config file through `update`. Unlike `viper.WriteConfig`, this also allows removing keys,
+// and never persists values that only come from flags.
+func Update(update func(settings map[string]any) error) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return ErrNotFound
+	}
+
+	settings := make(map[string]any)
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	if err := update(settings); err != nil {
+		return err
+	}
+
+	if data, err = yaml.Marshal(settings); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+
+	return viper.ReadInConfig()
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCreate(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the user config directory only follows XDG_CONFIG_HOME on Linux")
+	}
+
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("HOME", t.TempDir())
+
+	viper.Reset()
+	Init()
+
+	if err := Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	path, err := Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(configHome, "zube", "config.yml"); path != want {
+		t.Errorf("expected the config at %s, got %s", want, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the config to be readable only by the user, got %v", info.Mode().Perm())
+	}
+
+	if err := Update(func(settings map[string]any) error {
+		settings["client_id"] = "abc"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An existing config is kept
+	viper.Reset()
+	Init()
+	if path, err := Create(); err != nil || viper.GetString("client_id") != "abc" {
+		t.Errorf("expected the existing config at %s to be loaded, got %v", path, err)
+	}
+}
+
+func TestCreateAtPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "zube.yml")
+
+	viper.Reset()
+	Init()
+	viper.SetConfigFile(path)
+
+	if err := Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if created, err := Create(); err != nil || created != path {
+		t.Errorf("expected the config to be created at %s, got %s, %v", path, created, err)
+	}
+}
+
+func TestEnvironmentOverrides(t *testing.T) {
+	setupConfig(t, "client_id: abc\n")
+	Init()
+
+	t.Setenv("ZUBE_CLIENT_ID", "from-env")
+	setting, _ := LookupSetting("client_id")
+
+	if value, source := setting.Value(), setting.Source(nil); value != "from-env" || source != "env" {
+		t.Errorf("expected from-env from env, got %s from %s", value, source)
+	}
+}
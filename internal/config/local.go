@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Name of the per-repository config file, found in the working directory or any of its parents
+const LocalFileName = ".zube.yaml"
+
+// Settings a per-repository config file can set. Credentials can only be set by the user.
+var localKeys = []string{"default_project", "default_workspace", "default_source"}
+
+var localSettings map[string]any
+
+// Finds the closest per-repository config file, starting in `dir` and walking up
+func FindLocal(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, LocalFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Reads the closest per-repository config file, if any, on top of the user's config file
+func LoadLocal() error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	path, ok := FindLocal(wd)
+	if !ok {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]any)
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	for key := range settings {
+		if !contains(localKeys, key) {
+			return fmt.Errorf("%s can only set %s, not %s", path, strings.Join(localKeys, ", "), key)
+		}
+	}
+	if err := Validate(settings); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	localSettings = settings
+	return viper.MergeConfigMap(settings)
+}
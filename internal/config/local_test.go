@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestFindLocal(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "cmd", "zube")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, ok := FindLocal(nested); ok {
+		t.Fatalf("expected no local config, found %s", path)
+	}
+
+	want := filepath.Join(root, LocalFileName)
+	if err := os.WriteFile(want, []byte("default_project: Backend\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range []string{root, nested} {
+		if path, ok := FindLocal(dir); !ok || path != want {
+			t.Errorf("expected %s from %s, got %q", want, dir, path)
+		}
+	}
+}
+
+func TestLoadLocal(t *testing.T) {
+	root := t.TempDir()
+	chdir(t, root)
+	t.Cleanup(func() { localSettings = nil })
+
+	viper.Reset()
+	viper.Set("default_workspace", "Sprint")
+	viper.SetConfigType("yaml")
+	if err := viper.MergeConfigMap(map[string]any{"default_project": "Frontend", "default_source": "platogo/web"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, LocalFileName)
+	if err := os.WriteFile(path, []byte("default_project: Backend\ndefault_workspace: Kanban\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadLocal(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := viper.GetString("default_project"); got != "Backend" {
+		t.Errorf("expected the local default_project, got %q", got)
+	}
+	if got := viper.GetString("default_source"); got != "platogo/web" {
+		t.Errorf("expected default_source from the config file, got %q", got)
+	}
+	if got := viper.GetString("default_workspace"); got != "Sprint" {
+		t.Errorf("expected default_workspace from the override, got %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte("client_id: someone-else\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadLocal(); err == nil {
+		t.Error("expected an error for credentials in the local config")
+	}
+}
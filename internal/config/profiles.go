@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Credentials of one Zube account, for users working with several of them
+type Profile struct {
+	Name           string `json:"name" yaml:"name"`
+	ClientId       string `json:"client_id" yaml:"client_id"`
+	PrivateKeyPath string `json:"private_key_path" yaml:"private_key_path"`
+	// A PEM encoded private key, used instead of the key file. Only set from the top-level `private_key` setting.
+	PrivateKey string `json:"-" yaml:"-"`
+}
+
+// Returns all profiles, sorted by name
+func Profiles() []Profile {
+	var profiles []Profile
+
+	for name, value := range viper.GetStringMap("profiles") {
+		settings := cast.ToStringMapString(value)
+		profiles = append(profiles, Profile{Name: name, ClientId: settings["client_id"], PrivateKeyPath: settings["private_key_path"]})
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles
+}
+
+// Returns a profile by its case-insensitive name
+func GetProfile(name string) (Profile, error) {
+	for _, profile := range Profiles() {
+		if profile.Name == strings.ToLower(name) {
+			return profile, nil
+		}
+	}
+
+	return Profile{}, fmt.Errorf("profile %q not found, see `zube config profile ls` for all profiles", name)
+}
+
+// Returns the profile selected with `--profile`, `ZUBE_PROFILE` or `zube config profile use`, if any
+func ActiveProfile() (Profile, bool, error) {
+	name := viper.GetString("profile")
+	if name == "" {
+		return Profile{}, false, nil
+	}
+
+	profile, err := GetProfile(name)
+	return profile, err == nil, err
+}
+
+// Settings holding the top-level credentials
+var credentialKeys = []string{"client_id", "private_key", "private_key_path"}
+
+// Returns the credentials to create a client with: the active profile or, without one, the top-level
+// `private_key` or `private_key_path` settings as the "default" profile. Credentials set in the environment
+// take precedence over a profile that is only selected in the config file, as they do for every setting.
+// Returns false if neither is set, leaving the key file to the Zube library.
+func Credentials(flags *pflag.FlagSet) (Profile, bool, error) {
+	profileSetting, _ := LookupSetting("profile")
+	if source := profileSetting.Source(flags); source == "flag" || source == "env" || !credentialsInEnv() {
+		if profile, ok, err := ActiveProfile(); ok || err != nil {
+			return profile, ok, err
+		}
+	}
+
+	key, path := viper.GetString("private_key"), viper.GetString("private_key_path")
+	if key == "" && path == "" {
+		return Profile{}, false, nil
+	}
+
+	return Profile{Name: "default", ClientId: viper.GetString("client_id"), PrivateKeyPath: path, PrivateKey: key}, true, nil
+}
+
+func credentialsInEnv() bool {
+	for _, key := range credentialKeys {
+		setting, _ := LookupSetting(key)
+		if os.Getenv(setting.Env()) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Profile names are used as config keys and in `--profile`, so they are kept simple
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Checks that a profile name only has letters, digits, dashes and underscores
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(strings.ToLower(name)) {
+		return fmt.Errorf("invalid profile name %q, it can only have letters, digits, dashes and underscores", name)
+	}
+	return nil
+}
+
+// Saves a profile, replacing any profile with the same name. Profile names are case-insensitive.
+func SaveProfile(profile Profile) error {
+	if err := ValidateProfileName(profile.Name); err != nil {
+		return err
+	}
+
+	return Update(func(settings map[string]any) error {
+		profiles := cast.ToStringMap(settings["profiles"])
+		profiles[strings.ToLower(profile.Name)] = map[string]any{
+			"client_id":        profile.ClientId,
+			"private_key_path": profile.PrivateKeyPath,
+		}
+		settings["profiles"] = profiles
+		return nil
+	})
+}
+
+// Makes a profile the one used by default, or the top-level credentials if `name` is empty
+func UseProfile(name string) error {
+	if name != "" {
+		if _, err := GetProfile(name); err != nil {
+			return err
+		}
+	}
+
+	return Update(func(settings map[string]any) error {
+		if name == "" {
+			delete(settings, "profile")
+		} else {
+			settings["profile"] = strings.ToLower(name)
+		}
+		return nil
+	})
+}
+
+// Removes a profile, and stops using it by default
+func RemoveProfile(name string) error {
+	if _, err := GetProfile(name); err != nil {
+		return err
+	}
+
+	return Update(func(settings map[string]any) error {
+		profiles := cast.ToStringMap(settings["profiles"])
+		delete(profiles, strings.ToLower(name))
+
+		if len(profiles) == 0 {
+			delete(settings, "profiles")
+		} else {
+			settings["profiles"] = profiles
+		}
+
+		if cast.ToString(settings["profile"]) == strings.ToLower(name) {
+			delete(settings, "profile")
+		}
+		return nil
+	})
+}
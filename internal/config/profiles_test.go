@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestProfiles(t *testing.T) {
+	path := setupConfig(t, "client_id: abc\n")
+
+	work := Profile{Name: "work", ClientId: "work-id", PrivateKeyPath: "~/.ssh/work.pem"}
+	if err := SaveProfile(Profile{Name: "Work", ClientId: work.ClientId, PrivateKeyPath: work.PrivateKeyPath}); err != nil {
+		t.Fatal(err)
+	}
+
+	if profiles := Profiles(); !reflect.DeepEqual(profiles, []Profile{work}) {
+		t.Errorf("expected %+v got %+v", []Profile{work}, profiles)
+	}
+
+	if _, ok, err := ActiveProfile(); ok || err != nil {
+		t.Errorf("expected no active profile, got %v, %v", ok, err)
+	}
+
+	for _, name := range []string{"../x", "", "my work", "-work"} {
+		if err := SaveProfile(Profile{Name: name, ClientId: "id", PrivateKeyPath: "key.pem"}); err == nil {
+			t.Errorf("expected an error saving a profile named %q", name)
+		}
+	}
+
+	if err := UseProfile("home"); err == nil {
+		t.Error("expected an error using a missing profile")
+	}
+	if err := UseProfile("WORK"); err != nil {
+		t.Fatal(err)
+	}
+	if profile, ok, err := ActiveProfile(); !ok || err != nil || profile != work {
+		t.Errorf("expected the work profile to be active, got %+v, %v, %v", profile, ok, err)
+	}
+
+	if err := RemoveProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "client_id: abc\n" {
+		t.Errorf("expected only the client ID to be left in the config, got %q", data)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	setupConfig(t, "client_id: abc\nprofile: work\nprofiles:\n  work:\n    client_id: work-id\n    private_key_path: ~/.ssh/work.pem\n")
+	Init()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("profile", "", "")
+	viper.BindPFlag("profile", flags.Lookup("profile"))
+
+	work := Profile{Name: "work", ClientId: "work-id", PrivateKeyPath: "~/.ssh/work.pem"}
+	if profile, ok, err := Credentials(flags); !ok || err != nil || profile != work {
+		t.Errorf("expected the work profile from the config file, got %+v, %v, %v", profile, ok, err)
+	}
+
+	t.Setenv("ZUBE_CLIENT_ID", "ci-id")
+	t.Setenv("ZUBE_PRIVATE_KEY", "ci-key")
+
+	ci := Profile{Name: "default", ClientId: "ci-id", PrivateKeyPath: "", PrivateKey: "ci-key"}
+	if profile, ok, err := Credentials(flags); !ok || err != nil || profile.ClientId != ci.ClientId || profile.PrivateKey != ci.PrivateKey {
+		t.Errorf("expected the credentials from the environment, got %+v, %v, %v", profile, ok, err)
+	}
+
+	flags.Set("profile", "work")
+	if profile, ok, err := Credentials(flags); !ok || err != nil || profile != work {
+		t.Errorf("expected the work profile given as a flag, got %+v, %v, %v", profile, ok, err)
+	}
+}
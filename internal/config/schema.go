@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Types of settings
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeBool     = "bool"
+	TypeDuration = "duration"
+)
+
+// A known setting of the config file
+type Setting struct {
+	Key         string
+	Type        string
+	Values      []string // the allowed values, if only some are
+	Default     string
+	Description string
+	Flag        string // the global flag overriding the setting, if any
+	Secret      bool   // hidden when listing settings
+}
+
+// Every known setting. `views` and `profiles` are managed by their own commands.
+var Settings = []Setting{
+	{Key: "client_id", Type: TypeString, Description: "Zube Client ID"},
+	{Key: "private_key_path", Type: TypeString, Default: "~/.ssh/zube_api_key.pem", Description: "Path of the Zube private key .pem file"},
+	{Key: "private_key", Type: TypeString, Secret: true, Description: "Zube private key in PEM format, instead of a private key file"},
+	{Key: "profile", Type: TypeString, Flag: "profile", Description: "Profile used by default"},
+	{Key: "default_project", Type: TypeString, Description: "Project name or ID used when neither a project nor a workspace is given"},
+	{Key: "default_workspace", Type: TypeString, Description: "Workspace name or ID used when neither a project nor a workspace is given"},
+	{Key: "default_assignee", Type: TypeString, Description: "Assignee name or ID, or @me, preselected when creating cards"},
+	{Key: "default_source", Type: TypeString, Description: "GitHub source name or ID preselected when creating cards"},
+	{Key: "output", Type: TypeString, Values: []string{"table", "json", "yaml", "csv", "ndjson"}, Default: "table", Flag: "output", Description: "Default output format"},
+	{Key: "color", Type: TypeString, Values: []string{"always", "auto", "never"}, Default: "always", Description: "When to color the output, auto only colors it in a terminal"},
+	{Key: "cache_ttl", Type: TypeDuration, Description: "How long cached API responses are kept, e.g. 24h, forever if unset"},
+}
+
+// Top-level keys of the config file that are not settings
+var sections = []string{"views", "profiles"}
+
+// Returns a known setting by its key
+func LookupSetting(key string) (Setting, error) {
+	for _, setting := range Settings {
+		if setting.Key == key {
+			return setting, nil
+		}
+	}
+
+	keys := make([]string, len(Settings))
+	for i, setting := range Settings {
+		keys[i] = setting.Key
+	}
+	return Setting{}, fmt.Errorf("unknown setting %q, must be one of: %s", key, strings.Join(keys, ", "))
+}
+
+// Parses a value of the setting, returning it with the type it is stored with
+func (s Setting) Parse(value string) (any, error) {
+	var parsed any
+	var err error
+
+	switch s.Type {
+	case TypeInt:
+		parsed, err = strconv.Atoi(value)
+	case TypeBool:
+		parsed, err = strconv.ParseBool(value)
+	case TypeDuration:
+		_, err = time.ParseDuration(value)
+		parsed = value
+	default:
+		parsed = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q for %s", s.Type, value, s.Key)
+	}
+
+	if len(s.Values) > 0 && !contains(s.Values, value) {
+		return nil, fmt.Errorf("invalid value %q for %s, must be one of: %s", value, s.Key, strings.Join(s.Values, ", "))
+	}
+
+	return parsed, nil
+}
+
+// The environment variable overriding the setting, e.g. `ZUBE_CLIENT_ID`
+func (s Setting) Env() string {
+	return "ZUBE_" + strings.ToUpper(s.Key)
+}
+
+// Where the current value of a setting comes from: "flag", "env", "local" (`LocalFileName`), "file" or "default",
+// in order of precedence
+func (s Setting) Source(flags *pflag.FlagSet) string {
+	if s.Flag != "" {
+		if flag := flags.Lookup(s.Flag); flag != nil && flag.Changed {
+			return "flag"
+		}
+	}
+	if _, ok := os.LookupEnv(s.Env()); ok {
+		return "env"
+	}
+	if _, ok := localSettings[s.Key]; ok {
+		return "local"
+	}
+	if viper.InConfig(s.Key) {
+		return "file"
+	}
+	return "default"
+}
+
+// The current value of a setting, or its default
+func (s Setting) Value() string {
+	if value := cast.ToString(viper.Get(s.Key)); value != "" {
+		return value
+	}
+	return s.Default
+}
+
+// Sets a known setting in the config file, after validating its value
+func Set(key, value string) error {
+	setting, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := setting.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	return Update(func(settings map[string]any) error {
+		settings[key] = parsed
+		return nil
+	})
+}
+
+// Removes a known setting from the config file
+func Unset(key string) error {
+	if _, err := LookupSetting(key); err != nil {
+		return err
+	}
+
+	return Update(func(settings map[string]any) error {
+		delete(settings, key)
+		return nil
+	})
+}
+
+// Validates the settings of a whole config file, e.g. after it was edited by hand
+func Validate(settings map[string]any) error {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if contains(sections, key) {
+			continue
+		}
+
+		setting, err := LookupSetting(key)
+		if err != nil {
+			return err
+		}
+
+		value, err := cast.ToStringE(settings[key])
+		if err != nil {
+			return fmt.Errorf("invalid %s for %s", setting.Type, key)
+		}
+		if _, err := setting.Parse(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
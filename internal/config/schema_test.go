@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestSetAndUnset(t *testing.T) {
+	path := setupConfig(t, "client_id: abc\n")
+
+	for _, invalid := range [][2]string{{"output", "xml"}, {"cache_ttl", "a day"}, {"unknown", "value"}} {
+		if err := Set(invalid[0], invalid[1]); err == nil {
+			t.Errorf("expected an error setting %s to %q", invalid[0], invalid[1])
+		}
+	}
+
+	if err := Set("cache_ttl", "24h"); err != nil {
+		t.Fatal(err)
+	}
+	if viper.GetDuration("cache_ttl").Hours() != 24 {
+		t.Errorf("expected a cache TTL of 24h, got %v", viper.Get("cache_ttl"))
+	}
+
+	setting, _ := LookupSetting("cache_ttl")
+	if source := setting.Source(pflag.NewFlagSet("test", pflag.ContinueOnError)); source != "file" {
+		t.Errorf("expected the setting to come from the file, got %s", source)
+	}
+
+	if err := Unset("cache_ttl"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "client_id: abc\n" {
+		t.Errorf("expected only the client ID to be left in the config, got %q", data)
+	}
+}
+
+func TestSettingSource(t *testing.T) {
+	setupConfig(t, "output: yaml\n")
+	setting, _ := LookupSetting("output")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("output", "table", "")
+
+	if source := setting.Source(flags); source != "file" {
+		t.Errorf("expected file, got %s", source)
+	}
+
+	flags.Set("output", "json")
+	if source := setting.Source(flags); source != "flag" {
+		t.Errorf("expected flag, got %s", source)
+	}
+
+	color, _ := LookupSetting("color")
+	if source, value := color.Source(flags), color.Value(); source != "default" || value != "always" {
+		t.Errorf("expected the default always, got %s from %s", value, source)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := map[string]any{"client_id": "abc", "cache_ttl": "1h", "views": map[string]any{}}
+	if err := Validate(valid); err != nil {
+		t.Errorf("expected a valid config, got %v", err)
+	}
+
+	for _, invalid := range []map[string]any{{"output": "xml"}, {"clientid": "abc"}, {"cache_ttl": []any{1}}} {
+		if err := Validate(invalid); err == nil {
+			t.Errorf("expected %v to be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// A saved card query, stored as the `card ls` arguments it was saved with
+type View struct {
+	Name string   `json:"name" yaml:"name"`
+	Args []string `json:"args" yaml:"args"`
+}
+
+// Returns all saved views, sorted by name
+func Views() []View {
+	var views []View
+
+	for name, value := range viper.GetStringMap("views") {
+		settings := cast.ToStringMap(value)
+		views = append(views, View{Name: name, Args: cast.ToStringSlice(settings["args"])})
+	}
+
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+
+	return views
+}
+
+// Returns a saved view by its case-insensitive name
+func GetView(name string) (View, error) {
+	for _, view := range Views() {
+		if view.Name == strings.ToLower(name) {
+			return view, nil
+		}
+	}
+
+	return View{}, fmt.Errorf("view %q not found, see `zube view ls` for all saved views", name)
+}
+
+// Saves a view, replacing any view with the same name. View names are case-insensitive.
+func SaveView(view View) error {
+	return Update(func(settings map[string]any) error {
+		views := cast.ToStringMap(settings["views"])
+		views[strings.ToLower(view.Name)] = map[string]any{"args": view.Args}
+		settings["views"] = views
+		return nil
+	})
+}
+
+// Removes a saved view
+func RemoveView(name string) error {
+	if _, err := GetView(name); err != nil {
+		return err
+	}
+
+	return Update(func(settings map[string]any) error {
+		views := cast.ToStringMap(settings["views"])
+		delete(views, strings.ToLower(name))
+
+		if len(views) == 0 {
+			delete(settings, "views")
+		} else {
+			settings["views"] = views
+		}
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func setupConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestSaveAndRemoveView(t *testing.T) {
+	path := setupConfig(t, "client_id: abc\n")
+
+	if err := SaveView(View{Name: "Mine", Args: []string{"--assignee", "@me"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveView(View{Name: "bugs", Args: []string{"-q", "label:bug"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []View{
+		{Name: "bugs", Args: []string{"-q", "label:bug"}},
+		{Name: "mine", Args: []string{"--assignee", "@me"}},
+	}
+	if views := Views(); !reflect.DeepEqual(views, want) {
+		t.Errorf("expected %+v got %+v", want, views)
+	}
+
+	if view, err := GetView("MINE"); err != nil || view.Name != "mine" {
+		t.Errorf("expected to find view mine, got %+v, %v", view, err)
+	}
+
+	if err := RemoveView("mine"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveView("bugs"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveView("bugs"); err == nil {
+		t.Error("expected an error removing a missing view")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "client_id: abc\n" {
+		t.Errorf("expected only the client ID to be left in the config, got %q", data)
+	}
+}
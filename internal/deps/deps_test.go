@@ -0,0 +1,132 @@
+package deps
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// 1 blocks 2, 2 blocks 3, 3 blocks 1, and 4 blocks 2
+func testGraph(t *testing.T) *Graph {
+	return buildTestGraph(t, 2, map[int][]int{1: {2}, 2: {3}, 3: {1}, 4: {2}}, nil)
+}
+
+// Builds the graph of `root` from the cards each card blocks, counting the fetches of each card
+func buildTestGraph(t *testing.T, root int, blocks map[int][]int, fetches map[int]int) *Graph {
+	nodes := map[int]Node{1: {1, "#1 One", false}, 2: {2, "#2 Two", false}, 3: {3, "#3 Three", true}, 4: {4, "#4 Four", false}, 5: {5, "#5 Five", false}}
+
+	g, err := Build(nodes[root], func(id int) ([]Node, []Node, error) {
+		if fetches != nil {
+			fetches[id]++
+		}
+
+		var blockers, blocked []Node
+		for from, tos := range blocks {
+			for _, to := range tos {
+				if to == id {
+					blockers = append(blockers, nodes[from])
+				}
+				if from == id {
+					blocked = append(blocked, nodes[to])
+				}
+			}
+		}
+		return blockers, blocked, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	g := testGraph(t)
+
+	if len(g.Nodes) != 4 {
+		t.Errorf("got %d nodes", len(g.Nodes))
+	}
+	if got := g.BlockedBy(2); len(got) != 2 || got[0] != 1 || got[1] != 4 {
+		t.Errorf("blockers of 2: %v", got)
+	}
+
+	_, err := Build(Node{Id: 1}, func(int) ([]Node, []Node, error) { return nil, nil, errors.New("failed") })
+	if err == nil {
+		t.Error("expected the fetch error")
+	}
+}
+
+func TestBuildFollowsEachDirection(t *testing.T) {
+	// 4 blocks 2 and 5, so 5 is a sibling of 2 rather than a dependency
+	fetches := make(map[int]int)
+	g := buildTestGraph(t, 2, map[int][]int{4: {2, 5}, 2: {3}, 3: {1}}, fetches)
+
+	if _, ok := g.Nodes[5]; ok {
+		t.Errorf("expected the sibling 5 to be left out, got %v", g.Ids())
+	}
+	if len(g.Nodes) != 4 {
+		t.Errorf("expected 4 cards, got %v", g.Ids())
+	}
+	for id, count := range fetches {
+		if count > 1 {
+			t.Errorf("card %d was fetched %d times", id, count)
+		}
+	}
+}
+
+func TestTreeExpandsSharedCardsOnce(t *testing.T) {
+	// 1 blocks 2 and 3, which both block 4, which blocks 5
+	g := buildTestGraph(t, 1, map[int][]int{1: {2, 3}, 2: {4}, 3: {4}, 4: {5}}, nil)
+
+	var tree bytes.Buffer
+	Tree(&tree, g)
+	want := `#1 One
+├── blocks #2 Two
+│   └── blocks #4 Four
+│       └── blocks #5 Five
+└── blocks #3 Three (done)
+    └── blocks #4 Four (see above)
+`
+	if tree.String() != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", tree.String(), want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := testGraph(t)
+
+	var tree bytes.Buffer
+	Tree(&tree, g)
+	want := `#2 Two
+├── blocked by #1 One
+│   └── blocked by #3 Three (done)
+│       └── blocked by #2 Two (cycle)
+├── blocked by #4 Four
+└── blocks #3 Three (done)
+    └── blocks #1 One
+        └── blocks #2 Two (cycle)
+`
+	if tree.String() != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", tree.String(), want)
+	}
+
+	var dot bytes.Buffer
+	Dot(&dot, g)
+	for _, line := range []string{`  2 [label="#2 Two", penwidth=2];`, `  3 [label="#3 Three", style=dashed];`, "  4 -> 2;"} {
+		if !strings.Contains(dot.String(), line+"\n") {
+			t.Errorf("dot is missing %q:\n%s", line, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	if err := Render(&mermaid, g, "mermaid"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(mermaid.String(), "graph LR\n  c1[\"#1 One\"]\n") || !strings.Contains(mermaid.String(), "  c1 --> c2\n") {
+		t.Errorf("mermaid:\n%s", mermaid.String())
+	}
+
+	if err := Render(&mermaid, g, "svg"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
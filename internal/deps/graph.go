@@ -0,0 +1,134 @@
+// Package deps builds the graph of cards blocking each other, and renders it as Graphviz dot, Mermaid or a tree
+package deps
+
+import "sort"
+
+// A card in a dependency graph
+type Node struct {
+	Id    int
+	Label string // e.g. "#123 Fix export"
+	Done  bool
+}
+
+// The cards blocking a card, and the cards it blocks
+type Fetch func(id int) (blockers, blocked []Node, err error)
+
+// The transitive dependencies of a card: the cards blocking it, and the cards it blocks
+type Graph struct {
+	Root  int
+	Nodes map[int]Node
+	// Cards blocked by each card, sorted by ID
+	blocks map[int][]int
+	// Cards blocking each card, sorted by ID
+	blockedBy map[int][]int
+}
+
+func NewGraph(root Node) *Graph {
+	return &Graph{
+		Root:      root.Id,
+		Nodes:     map[int]Node{root.Id: root},
+		blocks:    make(map[int][]int),
+		blockedBy: make(map[int][]int),
+	}
+}
+
+// Builds the graph of the cards transitively blocking the root, and the cards it transitively blocks.
+// Each direction is followed on its own, so other cards blocked by the root's blockers are left out.
+// Every card is fetched at most once.
+func Build(root Node, fetch Fetch) (*Graph, error) {
+	g := NewGraph(root)
+
+	type relations struct{ blockers, blocked []Node }
+	fetched := make(map[int]relations)
+	relationsOf := func(id int) (relations, error) {
+		if r, ok := fetched[id]; ok {
+			return r, nil
+		}
+		blockers, blocked, err := fetch(id)
+		fetched[id] = relations{blockers, blocked}
+		return fetched[id], err
+	}
+
+	// Walks from the root in one direction, adding an edge for every card found
+	walk := func(next func(relations) []Node, addEdge func(from, to Node)) error {
+		visited := map[int]bool{root.Id: true}
+		queue := []int{root.Id}
+
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			r, err := relationsOf(id)
+			if err != nil {
+				return err
+			}
+
+			for _, node := range next(r) {
+				addEdge(g.Nodes[id], node)
+				if !visited[node.Id] {
+					visited[node.Id] = true
+					queue = append(queue, node.Id)
+				}
+			}
+		}
+		return nil
+	}
+
+	err := walk(func(r relations) []Node { return r.blockers }, func(card, blocker Node) { g.AddEdge(blocker, card) })
+	if err != nil {
+		return nil, err
+	}
+
+	err = walk(func(r relations) []Node { return r.blocked }, func(card, blocked Node) { g.AddEdge(card, blocked) })
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Adds the relationship of a card blocking another card. Relationships are only added once,
+// since both cards report them.
+func (g *Graph) AddEdge(blocker, blocked Node) {
+	if _, ok := g.Nodes[blocker.Id]; !ok {
+		g.Nodes[blocker.Id] = blocker
+	}
+	if _, ok := g.Nodes[blocked.Id]; !ok {
+		g.Nodes[blocked.Id] = blocked
+	}
+
+	g.blocks[blocker.Id] = insertSorted(g.blocks[blocker.Id], blocked.Id)
+	g.blockedBy[blocked.Id] = insertSorted(g.blockedBy[blocked.Id], blocker.Id)
+}
+
+// The cards a card blocks
+func (g *Graph) Blocks(id int) []int {
+	return g.blocks[id]
+}
+
+// The cards blocking a card
+func (g *Graph) BlockedBy(id int) []int {
+	return g.blockedBy[id]
+}
+
+// The IDs of every card, sorted
+func (g *Graph) Ids() []int {
+	ids := make([]int, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func insertSorted(ids []int, id int) []int {
+	i := sort.SearchInts(ids, id)
+	if i < len(ids) && ids[i] == id {
+		return ids
+	}
+
+	ids = append(ids, 0)
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+	return ids
+}
@@ -0,0 +1,141 @@
+package deps
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output formats of a graph
+var Formats = []string{"tree", "dot", "mermaid"}
+
+// Writes the graph in one of the `Formats`
+func Render(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case "tree":
+		Tree(w, g)
+	case "dot":
+		Dot(w, g)
+	case "mermaid":
+		Mermaid(w, g)
+	default:
+		return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+	return nil
+}
+
+// Writes the graph in the Graphviz dot language, with edges pointing from blocking to blocked cards
+func Dot(w io.Writer, g *Graph) {
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+
+	for _, id := range g.Ids() {
+		node := g.Nodes[id]
+		attributes := []string{"label=" + dotQuote(node.Label)}
+		if id == g.Root {
+			attributes = append(attributes, "penwidth=2")
+		}
+		if node.Done {
+			attributes = append(attributes, "style=dashed")
+		}
+		fmt.Fprintf(w, "  %d [%s];\n", id, strings.Join(attributes, ", "))
+	}
+
+	for _, id := range g.Ids() {
+		for _, blocked := range g.Blocks(id) {
+			fmt.Fprintf(w, "  %d -> %d;\n", id, blocked)
+		}
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Writes the graph as a Mermaid flowchart, with edges pointing from blocking to blocked cards
+func Mermaid(w io.Writer, g *Graph) {
+	fmt.Fprintln(w, "graph LR")
+
+	for _, id := range g.Ids() {
+		fmt.Fprintf(w, "  c%d[\"%s\"]\n", id, strings.ReplaceAll(g.Nodes[id].Label, `"`, "#quot;"))
+	}
+
+	for _, id := range g.Ids() {
+		for _, blocked := range g.Blocks(id) {
+			fmt.Fprintf(w, "  c%d --> c%d\n", id, blocked)
+		}
+	}
+
+	fmt.Fprintf(w, "  style c%d stroke-width:3px\n", g.Root)
+}
+
+// Writes the cards blocking the root card and the cards it blocks as trees, each branch following one direction.
+// A card reached again in the same direction is only expanded the first time.
+func Tree(w io.Writer, g *Graph) {
+	fmt.Fprintln(w, treeLabel(g.Nodes[g.Root]))
+
+	type branch struct {
+		prefix   string
+		ids      []int
+		next     func(int) []int
+		expanded map[int]bool
+	}
+
+	var children []branch
+	if blockers := g.BlockedBy(g.Root); len(blockers) > 0 {
+		children = append(children, branch{"blocked by ", blockers, g.BlockedBy, make(map[int]bool)})
+	}
+	if blocked := g.Blocks(g.Root); len(blocked) > 0 {
+		children = append(children, branch{"blocks ", blocked, g.Blocks, make(map[int]bool)})
+	}
+
+	count := 0
+	for _, child := range children {
+		count += len(child.ids)
+	}
+
+	i := 0
+	for _, child := range children {
+		for _, id := range child.ids {
+			i++
+			writeTree(w, g, id, child.prefix, "", i == count, map[int]bool{g.Root: true}, child.expanded, child.next)
+		}
+	}
+}
+
+func writeTree(w io.Writer, g *Graph, id int, relation, indent string, last bool, path, expanded map[int]bool, next func(int) []int) {
+	branch, childIndent := "├── ", indent+"│   "
+	if last {
+		branch, childIndent = "└── ", indent+"    "
+	}
+
+	if path[id] {
+		fmt.Fprintf(w, "%s%s%s%s (cycle)\n", indent, branch, relation, treeLabel(g.Nodes[id]))
+		return
+	}
+
+	children := next(id)
+	if expanded[id] && len(children) > 0 {
+		fmt.Fprintf(w, "%s%s%s%s (see above)\n", indent, branch, relation, treeLabel(g.Nodes[id]))
+		return
+	}
+	fmt.Fprintf(w, "%s%s%s%s\n", indent, branch, relation, treeLabel(g.Nodes[id]))
+
+	expanded[id] = true
+	path[id] = true
+	defer delete(path, id)
+
+	for i, child := range children {
+		writeTree(w, g, child, relation, childIndent, i == len(children)-1, path, expanded, next)
+	}
+}
+
+func treeLabel(node Node) string {
+	if node.Done {
+		return node.Label + " (done)"
+	}
+	return node.Label
+}
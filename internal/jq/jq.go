@@ -0,0 +1,60 @@
+// Package jq filters JSON output with jq programs, using gojq for the full jq language.
+package jq
+
+import (
+	"errors"
+
+	"github.com/itchyny/gojq"
+)
+
+// A parsed and compiled jq program
+type Query struct {
+	source string
+	code   *gojq.Code
+}
+
+// Parses a jq program. Unknown functions and variables are reported here, before the program runs.
+func Parse(source string) (*Query, error) {
+	parsed, err := gojq.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{source: source, code: code}, nil
+}
+
+// Runs the program against a JSON-like value made of maps, slices, strings, numbers, booleans and nil
+func (q *Query) Run(input any) ([]any, error) {
+	results := []any{}
+
+	iter := q.code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return results, nil
+		}
+
+		if err, ok := v.(error); ok {
+			// `halt` stops the program without an error, unlike `halt_error`
+			var halt interface {
+				IsHaltError() bool
+				Value() any
+			}
+			if errors.As(err, &halt) && halt.IsHaltError() && halt.Value() == nil {
+				return results, nil
+			}
+			return nil, err
+		}
+
+		results = append(results, v)
+	}
+}
+
+func (q *Query) String() string {
+	return q.source
+}
@@ -0,0 +1,127 @@
+package jq
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const cards = `[
+	{"number": 3, "title": "Fix login", "status": "in_progress", "priority": 2, "labels": [{"name": "bug"}]},
+	{"number": 1, "title": "Add export", "status": "done", "priority": null, "labels": []},
+	{"number": 2, "title": "Fix typo", "status": "done", "priority": 5, "labels": [{"name": "bug"}, {"name": "docs"}]}
+]`
+
+func TestRun(t *testing.T) {
+	var input any
+	if err := json.Unmarshal([]byte(cards), &input); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		program string
+		want    string
+	}{
+		{".", cards},
+		{".[] | .number", `[3, 1, 2]`},
+		{"[.[].number]", `[[3, 1, 2]]`},
+		{".[0].title", `["Fix login"]`},
+		{".[-1].number", `[2]`},
+		{`.[] | select(.status == "done") | .number`, `[1, 2]`},
+		{`map(select(.priority != null and .priority <= 2)) | length`, `[1]`},
+		{`sort_by(.number) | map(.title)`, `[["Add export", "Fix typo", "Fix login"]]`},
+		{`group_by(.status) | map({status: .[0].status, count: length})`, `[[{"status": "done", "count": 2}, {"status": "in_progress", "count": 1}]]`},
+		{`.[] | {number, labels: (.labels | map(.name) | join(","))}`, `[{"number": 3, "labels": "bug"}, {"number": 1, "labels": ""}, {"number": 2, "labels": "bug,docs"}]`},
+		{`.[1].priority // "none"`, `["none"]`},
+		{`.[] | select(.title | test("^Fix")) | .number + 100`, `[103, 102]`},
+		{`map(.labels[]?.name) | unique`, `[["bug", "docs"]]`},
+		{`.[0] | keys`, `[["labels", "number", "priority", "status", "title"]]`},
+		{`.[0].title, .[2].title`, `["Fix login", "Fix typo"]`},
+		{`map(.priority) | add`, `[7]`},
+		{`.[] | select(.labels | any) | .number`, `[3, 2]`},
+		{`.[0].priority as $p | map(select(.priority == $p)) | length`, `[1]`},
+		{`[.. | .name? // empty]`, `[["bug", "bug", "docs"]]`},
+		{`max_by(.number).title`, `["Fix login"]`},
+		{`unique_by(.status) | map(.number)`, `[[1, 3]]`},
+		{`first(.[] | select(.status == "done")) | .number`, `[1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.program, func(t *testing.T) {
+			query, err := Parse(tt.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := query.Run(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if tt.program == "." {
+				want = []any{want}
+			}
+
+			// Compare through JSON, since gojq may return integers where the input had floats
+			got, _ := json.Marshal(res)
+			var normalized any
+			if err := json.Unmarshal(got, &normalized); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(normalized, want) {
+				t.Errorf("expected %s got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		".[",
+		".foo |",
+		"unknown_function",
+		`"unterminated`,
+		".a ] .b",
+		"{a: }",
+		"$undefined",
+	}
+
+	for _, program := range tests {
+		t.Run(program, func(t *testing.T) {
+			if _, err := Parse(program); err == nil {
+				t.Errorf("expected an error parsing %q", program)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	query, _ := Parse(".title.name")
+
+	if _, err := query.Run(map[string]any{"title": "x"}); err == nil {
+		t.Error("expected an error indexing a string")
+	}
+
+	query, _ = Parse(".title.name?")
+
+	if res, err := query.Run(map[string]any{"title": "x"}); err != nil || len(res) != 0 {
+		t.Errorf("expected no outputs and no error, got %v and %v", res, err)
+	}
+
+	query, _ = Parse(`1, halt, 2`)
+
+	if res, err := query.Run(nil); err != nil || len(res) != 1 {
+		t.Errorf("expected halt to stop after one output, got %v and %v", res, err)
+	}
+
+	query, _ = Parse(`"failed" | halt_error`)
+
+	if _, err := query.Run(nil); err == nil {
+		t.Error("expected an error from halt_error")
+	}
+}
@@ -0,0 +1,132 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/logrusorgru/aurora/v4"
+)
+
+// Syntax of a language, for highlighting code blocks
+type syntax struct {
+	keywords   map[string]bool
+	comment    string // line comment prefix
+	ignoreCase bool
+}
+
+func newSyntax(comment string, keywords string) syntax {
+	s := syntax{keywords: make(map[string]bool), comment: comment}
+	for _, keyword := range strings.Fields(keywords) {
+		s.keywords[keyword] = true
+	}
+	return s
+}
+
+var (
+	goSyntax = newSyntax("//", `break case chan const continue default defer else fallthrough for func go goto if
+		import interface map package range return select struct switch type var nil true false iota`)
+	jsSyntax = newSyntax("//", `async await break case catch class const continue default delete do else export extends
+		false finally for from function if import in instanceof interface let new null return switch this throw true
+		try type typeof undefined var void while yield`)
+	pythonSyntax = newSyntax("#", `and as assert async await break class continue def del elif else except False finally
+		for from global if import in is lambda None nonlocal not or pass raise return True try while with yield`)
+	shellSyntax = newSyntax("#", `if then else elif fi for in do done while until case esac function return export
+		local echo exit set unset`)
+	rubySyntax = newSyntax("#", `begin class def do else elsif end ensure false if in module next nil not or
+		require rescue return self then true unless until when while yield`)
+	sqlSyntax = syntax{
+		keywords: newSyntax("", `select from where and or not insert into values update set delete create table alter
+			drop index join left right inner outer on group by order having limit offset as null is in like distinct`).keywords,
+		comment:    "--",
+		ignoreCase: true,
+	}
+	dataSyntax = newSyntax("#", `true false null yes no`)
+)
+
+var syntaxes = map[string]syntax{
+	"go":         goSyntax,
+	"golang":     goSyntax,
+	"js":         jsSyntax,
+	"javascript": jsSyntax,
+	"jsx":        jsSyntax,
+	"ts":         jsSyntax,
+	"typescript": jsSyntax,
+	"tsx":        jsSyntax,
+	"py":         pythonSyntax,
+	"python":     pythonSyntax,
+	"sh":         shellSyntax,
+	"bash":       shellSyntax,
+	"shell":      shellSyntax,
+	"zsh":        shellSyntax,
+	"console":    shellSyntax,
+	"rb":         rubySyntax,
+	"ruby":       rubySyntax,
+	"elixir":     rubySyntax,
+	"ex":         rubySyntax,
+	"sql":        sqlSyntax,
+	"json":       dataSyntax,
+	"yaml":       dataSyntax,
+	"yml":        dataSyntax,
+	"toml":       dataSyntax,
+}
+
+var tokenPattern = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"?|'(?:[^'\\\\]|\\\\.)*'?|`[^`]*`?|\\b\\d+(?:\\.\\d+)?\\b|[A-Za-z_][A-Za-z0-9_]*")
+
+// Highlights a line of code in the given language. Lines of unknown languages are returned as they are.
+func highlight(line, language string) string {
+	s, ok := syntaxes[strings.ToLower(language)]
+	if !ok {
+		return line
+	}
+
+	code, comment := line, ""
+	if s.comment != "" {
+		if i := commentStart(line, s.comment); i >= 0 {
+			code, comment = line[:i], line[i:]
+		}
+	}
+
+	highlighted := tokenPattern.ReplaceAllStringFunc(code, func(token string) string {
+		switch {
+		case strings.ContainsAny(token[:1], "\"'`"):
+			return Green(token).String()
+		case token[0] >= '0' && token[0] <= '9':
+			return Magenta(token).String()
+		case s.keywords[token] || s.ignoreCase && s.keywords[strings.ToLower(token)]:
+			return Blue(token).Bold().String()
+		}
+		return token
+	})
+
+	if comment != "" {
+		highlighted += Gray(12, comment).String()
+	}
+
+	return highlighted
+}
+
+// Finds the start of a line comment outside of string literals, or -1
+func commentStart(line, prefix string) int {
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case strings.HasPrefix(line[i:], prefix):
+			// `#` only starts a comment at the start of a word, e.g. not in `$#` or `a#b`
+			if prefix == "#" && i > 0 && line[i-1] != ' ' && line[i-1] != '\t' {
+				continue
+			}
+			return i
+		}
+	}
+
+	return -1
+}
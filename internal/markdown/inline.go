@@ -0,0 +1,183 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+// Characters that can be escaped with a backslash
+const escapable = "\\`*_{}[]()<>#+-.!|~"
+
+var autolinkPattern = regexp.MustCompile(`^<((?:https?|mailto):[^>\s]+)>`)
+
+// Renders the inline Markdown of a paragraph, heading or table cell: emphasis, strikethrough,
+// code spans, links, images and autolinks
+func inline(s string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.IndexByte(escapable, rest[1]) >= 0:
+			out.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if code, n := codeSpan(rest); n > 0 {
+				out.WriteString(Cyan(code).String())
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n := delimited(rest, rest[:2], i == 0 || !isWordChar(s, i-1)); n > 0 {
+				out.WriteString(Bold(inline(inner)).String())
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n := delimited(rest, "~~", true); n > 0 {
+				out.WriteString(CrossedOut(inline(inner)).String())
+				i += n
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			if inner, n := delimited(rest, rest[:1], i == 0 || !isWordChar(s, i-1)); n > 0 {
+				out.WriteString(Italic(inline(inner)).String())
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "!["):
+			if text, url, n := link(rest[1:]); n > 0 {
+				out.WriteString(Gray(14, "[image: "+text+"]").String() + " " + Gray(12, url).String())
+				i += n + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if text, url, n := link(rest); n > 0 {
+				out.WriteString(formatLink(inline(text), url))
+				i += n
+				continue
+			}
+
+		case rest[0] == '<':
+			if match := autolinkPattern.FindStringSubmatch(rest); match != nil {
+				out.WriteString(formatLink(match[1], match[1]))
+				i += len(match[0])
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		out.WriteString(rest[:size])
+		i += size
+	}
+
+	return out.String()
+}
+
+func formatLink(text, url string) string {
+	link := Underline(BrightBlue(text)).String()
+	if ansi.Strip(text) == url {
+		return link
+	}
+	return link + " " + Gray(12, "("+url+")").String()
+}
+
+// Parses a code span opened by a run of backticks, returning its trimmed content and length
+func codeSpan(s string) (string, int) {
+	ticks := len(s) - len(strings.TrimLeft(s, "`"))
+	fence := s[:ticks]
+
+	end := strings.Index(s[ticks:], fence)
+	if end < 0 {
+		return "", 0
+	}
+
+	return strings.TrimSpace(s[ticks : ticks+end]), ticks + end + ticks
+}
+
+// Parses a span enclosed by `delimiter`, e.g. `**bold**`, returning its content and length. The content
+// must not start or end with a space, and underscores only delimit spans at word boundaries.
+func delimited(s, delimiter string, leftBoundary bool) (string, int) {
+	if delimiter[0] == '_' && !leftBoundary {
+		return "", 0
+	}
+
+	start := len(delimiter)
+	for offset := start; offset < len(s); {
+		end := strings.Index(s[offset:], delimiter)
+		if end < 0 {
+			return "", 0
+		}
+		end += offset
+
+		inner := s[start:end]
+		closes := inner != "" && strings.TrimSpace(inner) == inner &&
+			// `*a **b** c*` must not close at the inner `**`
+			!(len(delimiter) == 1 && strings.HasPrefix(s[end:], delimiter+delimiter)) &&
+			(delimiter[0] != '_' || end+len(delimiter) >= len(s) || !isWordChar(s, end+len(delimiter)))
+
+		if closes {
+			return inner, end + len(delimiter)
+		}
+		offset = end + len(delimiter)
+		if len(delimiter) == 1 && strings.HasPrefix(s[end:], delimiter+delimiter) {
+			offset++
+		}
+	}
+
+	return "", 0
+}
+
+// Parses a `[text](url)` link, returning its text, URL and length
+func link(s string) (string, string, int) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", "", 0
+			}
+
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0
+			}
+
+			// A link title, as in `[text](url "title")`, is not shown
+			url, _, _ := strings.Cut(strings.TrimSpace(s[i+2:i+2+end]), " ")
+			return s[1:i], url, i + 2 + end + 1
+		}
+	}
+
+	return "", "", 0
+}
+
+func isWordChar(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	if !utf8.RuneStart(s[i]) {
+		r, _ = utf8.DecodeLastRuneInString(s[:i+1])
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,302 @@
+// Package markdown renders Markdown as formatted text for the terminal: headings, paragraphs, emphasis,
+// links, block quotes, nested and task lists, syntax highlighted code blocks and tables, wrapped to
+// the terminal width.
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+// Width used when the terminal width is unknown
+const DefaultWidth = 80
+
+// Text is never wrapped narrower than this, even inside nested quotes and lists. It overflows instead.
+const minWidth = 10
+
+var (
+	headingPattern   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	rulePattern      = regexp.MustCompile(`^ {0,3}(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	fencePattern     = regexp.MustCompile("^( {0,3})(```+|~~~+)\\s*([^`\\s]*)")
+	quotePattern     = regexp.MustCompile(`^ {0,3}>\s?`)
+	listItemPattern  = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(\s+|$)`)
+	taskPattern      = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	delimiterPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// Renders Markdown source as formatted text, wrapped to `width` columns
+func Render(source string, width int) string {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := renderBlocks(strings.Split(strings.TrimRight(source, "\n"), "\n"), width)
+
+	return strings.Join(lines, "\n")
+}
+
+// Renders a sequence of blocks, separated by blank lines
+func renderBlocks(lines []string, width int) []string {
+	var out []string
+	if width < minWidth {
+		width = minWidth
+	}
+
+	appendBlock := func(block []string) {
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		out = append(out, block...)
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case isBlank(line):
+			i++
+
+		case fencePattern.MatchString(line):
+			block, n := renderCodeBlock(lines[i:], width)
+			appendBlock(block)
+			i += n
+
+		case headingPattern.MatchString(line):
+			appendBlock(renderHeading(line, width))
+			i++
+
+		case rulePattern.MatchString(line):
+			appendBlock([]string{Gray(8, strings.Repeat("─", width)).String()})
+			i++
+
+		case quotePattern.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && !isBlank(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.ReplaceAllString(lines[i], ""))
+			}
+			bar := Gray(12, "│ ").String()
+			appendBlock(indent(renderBlocks(quoted, width-2), bar, bar))
+
+		case isTableStart(lines[i:]):
+			block, n := renderTable(lines[i:], width)
+			appendBlock(block)
+			i += n
+
+		case listItemPattern.MatchString(line):
+			block, n := renderList(lines[i:], width)
+			appendBlock(block)
+			i += n
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && !isBlank(lines[i]) && (len(paragraph) == 0 || !startsBlock(lines[i:])); i++ {
+				paragraph = append(paragraph, lines[i])
+			}
+			appendBlock(renderParagraph(paragraph, width))
+		}
+	}
+
+	return out
+}
+
+// Whether the lines start a block that interrupts a paragraph
+func startsBlock(lines []string) bool {
+	line := lines[0]
+	return fencePattern.MatchString(line) || headingPattern.MatchString(line) || rulePattern.MatchString(line) ||
+		quotePattern.MatchString(line) || listItemPattern.MatchString(line) || isTableStart(lines)
+}
+
+func renderParagraph(lines []string, width int) []string {
+	var text strings.Builder
+
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		text.WriteString(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+
+		if i < len(lines)-1 {
+			if hardBreak {
+				text.WriteByte('\n')
+			} else {
+				text.WriteByte(' ')
+			}
+		}
+	}
+
+	return wrap(inline(text.String()), width)
+}
+
+func renderHeading(line string, width int) []string {
+	match := headingPattern.FindStringSubmatch(line)
+	level, text := len(match[1]), inline(match[2])
+
+	var lines []string
+	for _, l := range wrap(text, width) {
+		switch level {
+		case 1:
+			lines = append(lines, Bold(Underline(BrightMagenta(l))).String())
+		case 2:
+			lines = append(lines, Bold(BrightMagenta(l)).String())
+		default:
+			lines = append(lines, Bold(l).String())
+		}
+	}
+	return lines
+}
+
+// Renders a fenced code block, returning its lines and the number of source lines it spans
+func renderCodeBlock(lines []string, width int) ([]string, int) {
+	match := fencePattern.FindStringSubmatch(lines[0])
+	fenceIndent, fence, language := len(match[1]), match[2], match[3]
+
+	var block []string
+	n := 1
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			n++
+			break
+		}
+
+		code := strings.ReplaceAll(trimIndent(lines[n], fenceIndent), "\t", "    ")
+		block = append(block, highlight(code, language))
+	}
+
+	if language != "" {
+		block = append([]string{Gray(8, language).String()}, block...)
+	}
+
+	return indent(block, "  ", "  "), n
+}
+
+// Renders a list and its nested blocks, returning its lines and the number of source lines it spans
+func renderList(lines []string, width int) ([]string, int) {
+	first := listItemPattern.FindStringSubmatch(lines[0])
+	listIndent := len(first[1])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+
+	var out []string
+	number := 0
+	fmt.Sscan(strings.TrimRight(first[2], ".)"), &number)
+
+	n := 0
+	for n < len(lines) {
+		match := listItemPattern.FindStringSubmatch(lines[n])
+		if match == nil || len(match[1]) != listIndent || (match[2][0] >= '0' && match[2][0] <= '9') != ordered {
+			break
+		}
+
+		// The item content is the rest of its line, and the following lines indented past its marker,
+		// or lazily continuing its first paragraph
+		contentIndent := len(match[0])
+		if match[3] == "" || len(match[3]) > 4 {
+			contentIndent = len(match[1]) + len(match[2]) + 1
+		}
+
+		content := []string{lines[n][len(match[0]):]}
+		n++
+
+		for n < len(lines) {
+			line := lines[n]
+
+			if isBlank(line) {
+				// A blank line only continues the item if it is followed by indented content
+				next := n + 1
+				for next < len(lines) && isBlank(lines[next]) {
+					next++
+				}
+				if next >= len(lines) || leadingSpaces(lines[next]) < contentIndent {
+					break
+				}
+				content = append(content, "")
+				n++
+				continue
+			}
+
+			if leadingSpaces(line) >= contentIndent {
+				content = append(content, trimIndent(line, contentIndent))
+				n++
+				continue
+			}
+
+			lastContent := content[len(content)-1]
+			if !isBlank(lastContent) && !startsBlock(lines[n:]) {
+				content = append(content, strings.TrimSpace(line))
+				n++
+				continue
+			}
+
+			break
+		}
+
+		marker := Yellow("•").String()
+		if ordered {
+			marker = Yellow(fmt.Sprintf("%d.", number)).String()
+			number++
+		}
+
+		if task := taskPattern.FindStringSubmatch(content[0]); task != nil {
+			content[0] = content[0][len(task[0]):]
+			if task[1] == " " {
+				marker = Gray(14, "☐").String()
+			} else {
+				marker = Green("☑").String()
+			}
+		}
+
+		markerWidth := ansi.Width(marker) + 1
+		rendered := renderItem(content, width-markerWidth)
+		out = append(out, indent(rendered, marker+" ", strings.Repeat(" ", markerWidth))...)
+
+		// Skip blank lines between items of the same list
+		next := n
+		for next < len(lines) && isBlank(lines[next]) {
+			next++
+		}
+		if next > n && next < len(lines) {
+			if match := listItemPattern.FindStringSubmatch(lines[next]); match != nil && len(match[1]) == listIndent {
+				n = next
+			}
+		}
+	}
+
+	return out, n
+}
+
+// Renders the content of a list item. Items are kept compact, so paragraphs and nested lists directly
+// follow each other.
+func renderItem(content []string, width int) []string {
+	var out []string
+
+	for i := 0; i < len(content); {
+		if isBlank(content[i]) {
+			i++
+			continue
+		}
+
+		if listItemPattern.MatchString(content[i]) {
+			block, n := renderList(content[i:], width)
+			out = append(out, block...)
+			i += n
+			continue
+		}
+
+		end := i + 1
+		for end < len(content) && !isBlank(content[end]) && !listItemPattern.MatchString(content[end]) {
+			end++
+		}
+
+		block := renderBlocks(content[i:end], width)
+		if len(out) > 0 && !listItemPattern.MatchString(content[i]) && isBlank(content[i-1]) {
+			out = append(out, "")
+		}
+		out = append(out, block...)
+		i = end
+	}
+
+	return out
+}
@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+func renderPlain(source string, width int) string {
+	return ansi.Strip(Render(source, width))
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		width  int
+		want   string
+	}{
+		{
+			"headings and paragraphs",
+			"# Export\n\nTimestamps are\nwrong in **all** exports, see [the docs](https://zube.io/docs).",
+			30,
+			`
+Export
+
+Timestamps are wrong in all
+exports, see the docs
+(https://zube.io/docs).`,
+		},
+		{
+			"lists and tasks",
+			"- [x] reproduce\n- [ ] fix\n  1. parse\n  2. format\n* plain",
+			80,
+			`
+☑ reproduce
+☐ fix
+  1. parse
+  2. format
+• plain`,
+		},
+		{
+			"code blocks",
+			"```go\nfunc main() {}\n```\n\n> quoted `code`",
+			80,
+			`
+  go
+  func main() {}
+
+│ quoted code`,
+		},
+		{
+			"tables",
+			"| Name | Count |\n| --- | ---: |\n| bugs | 12 |\n| escaped \\| pipe | 3 |",
+			80,
+			`
+┌────────────────┬───────┐
+│ Name           │ Count │
+├────────────────┼───────┤
+│ bugs           │    12 │
+│ escaped | pipe │     3 │
+└────────────────┴───────┘`,
+		},
+		{
+			"wrapped tables",
+			"| Step | Description |\n| --- | --- |\n| 1 | Fetch every page of cards |",
+			26,
+			`
+┌──────┬─────────────────┐
+│ Step │ Description     │
+├──────┼─────────────────┤
+│ 1    │ Fetch every     │
+│      │ page of cards   │
+└──────┴─────────────────┘`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := renderPlain(tt.source, tt.width), strings.TrimPrefix(tt.want, "\n"); got != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderSmallWidths(t *testing.T) {
+	source := "# Export\n\n> quoted\n> > ---\n\n1. item\n   - [ ] nested\n     > ---\n\n" +
+		"```go\nfunc main() {}\n```\n\n| Name | Count |\n| --- | ---: |\n| bugs | 12 |\n\n---"
+
+	for width := 1; width <= minWidth; width++ {
+		got := renderPlain(source, width)
+		if !strings.Contains(got, "quoted") || !strings.Contains(got, "bugs") {
+			t.Errorf("unexpected output at width %d:\n%s", width, got)
+		}
+	}
+
+	if got, want := renderPlain("quoted\n\n---", 1), "quoted\n\n"+strings.Repeat("─", minWidth); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"2 * 3 * 4", "2 * 3 * 4"},
+		{"*a **b** c* and ~~gone~~", "a b c and gone"},
+		{`\*not emphasis\*`, "*not emphasis*"},
+		{"![screenshot](shot.png)", "[image: screenshot] shot.png"},
+		{"<https://zube.io>", "https://zube.io"},
+	}
+
+	for _, tt := range tests {
+		if got := ansi.Strip(inline(tt.source)); got != tt.want {
+			t.Errorf("inline(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package markdown
+
+import (
+	"strings"
+
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+// Table columns are never narrower than this, even if the table doesn't fit
+const minColumnWidth = 6
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignCenter
+	alignRight
+)
+
+// Whether the lines start a table: a header row followed by a delimiter row like `| --- | :-: |`
+func isTableStart(lines []string) bool {
+	return len(lines) > 1 && strings.Contains(lines[0], "|") && strings.Contains(lines[1], "-") &&
+		delimiterPattern.MatchString(lines[1])
+}
+
+// Splits a table row into its cells, honoring escaped pipes and pipes in code spans
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	inCode := false
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			inCode = !inCode
+			cell.WriteByte(c)
+		case c == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// Renders a table with box drawing borders. Columns that don't fit the width are wrapped.
+// Returns the lines and the number of source lines the table spans.
+func renderTable(lines []string, width int) ([]string, int) {
+	header := splitRow(lines[0])
+
+	var alignments []alignment
+	for _, delimiter := range splitRow(lines[1]) {
+		switch {
+		case strings.HasPrefix(delimiter, ":") && strings.HasSuffix(delimiter, ":"):
+			alignments = append(alignments, alignCenter)
+		case strings.HasSuffix(delimiter, ":"):
+			alignments = append(alignments, alignRight)
+		default:
+			alignments = append(alignments, alignLeft)
+		}
+	}
+
+	n := 2
+	rows := [][]string{renderCells(header, len(header))}
+	for ; n < len(lines) && strings.Contains(lines[n], "|") && !isBlank(lines[n]); n++ {
+		rows = append(rows, renderCells(splitRow(lines[n]), len(header)))
+	}
+
+	widths := columnWidths(rows, width)
+
+	border := func(left, middle, right string) string {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		return Gray(8, left+strings.Join(parts, middle)+right).String()
+	}
+
+	out := []string{border("┌", "┬", "┐")}
+	for i, row := range rows {
+		out = append(out, renderRow(row, widths, alignments, i == 0)...)
+		if i == 0 {
+			out = append(out, border("├", "┼", "┤"))
+		}
+	}
+	out = append(out, border("└", "┴", "┘"))
+
+	return out, n
+}
+
+// Renders the inline Markdown of each cell, padding or cutting the row to `columns` cells
+func renderCells(cells []string, columns int) []string {
+	rendered := make([]string, columns)
+	for i := range rendered {
+		if i < len(cells) {
+			rendered[i] = inline(cells[i])
+		}
+	}
+	return rendered
+}
+
+// Sizes every column to its widest cell. If the table is wider than `width`, the widest columns are
+// narrowed until it fits.
+func columnWidths(rows [][]string, width int) []int {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := ansi.Width(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	// Every column has a border and padding on each side
+	available := width - 3*len(widths) - 1
+
+	for total := sum(widths); total > available; total-- {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+	}
+
+	return widths
+}
+
+// Renders a row, wrapping cells to their column width. Returns one line per wrapped cell line.
+func renderRow(row []string, widths []int, alignments []alignment, header bool) []string {
+	wrapped := make([][]string, len(row))
+	height := 1
+
+	for i, cell := range row {
+		if header {
+			cell = Bold(cell).String()
+		}
+		wrapped[i] = wrap(cell, widths[i])
+		if len(wrapped[i]) > height {
+			height = len(wrapped[i])
+		}
+	}
+
+	bar := Gray(8, "│").String()
+	lines := make([]string, height)
+
+	for l := range lines {
+		var line strings.Builder
+		line.WriteString(bar)
+
+		for i, cellLines := range wrapped {
+			var text string
+			if l < len(cellLines) {
+				text = cellLines[l]
+			}
+
+			align := alignLeft
+			if i < len(alignments) {
+				align = alignments[i]
+			}
+
+			line.WriteString(" " + alignText(text, widths[i], align) + " " + bar)
+		}
+
+		lines[l] = line.String()
+	}
+
+	return lines
+}
+
+func alignText(text string, width int, align alignment) string {
+	gap := width - ansi.Width(text)
+	if gap <= 0 {
+		return text
+	}
+
+	switch align {
+	case alignRight:
+		return strings.Repeat(" ", gap) + text
+	case alignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	}
+	return pad(text, width)
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
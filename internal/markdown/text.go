@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+// Word wraps styled text to lines of at most `width` visible characters. Words longer than a line are
+// kept whole, explicit newlines are kept as line breaks.
+func wrap(text string, width int) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line strings.Builder
+		lineWidth := 0
+
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := ansi.Width(word)
+
+			if lineWidth > 0 && width > 0 && lineWidth+1+wordWidth > width {
+				lines = append(lines, line.String())
+				line.Reset()
+				lineWidth = 0
+			}
+
+			if lineWidth > 0 {
+				line.WriteByte(' ')
+				lineWidth++
+			}
+			line.WriteString(word)
+			lineWidth += wordWidth
+		}
+
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+// Pads styled text with spaces to a visible width
+func pad(s string, width int) string {
+	if gap := width - ansi.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// Prefixes every line, using `first` for the first line only
+func indent(lines []string, first, rest string) []string {
+	indented := make([]string, len(lines))
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+
+		if line == "" {
+			indented[i] = strings.TrimRight(prefix, " ")
+		} else {
+			indented[i] = prefix + line
+		}
+	}
+	return indented
+}
+
+// Returns the number of leading spaces, counting tabs as four
+func leadingSpaces(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// Removes up to `n` leading spaces
+func trimIndent(line string, n int) string {
+	for n > 0 && line != "" {
+		switch line[0] {
+		case ' ':
+			n--
+		case '\t':
+			n -= 4
+		default:
+			return line
+		}
+		line = line[1:]
+	}
+	return line
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
@@ -0,0 +1,417 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/logrusorgru/aurora/v4"
+)
+
+// A column of a board
+type BoardColumn struct {
+	Name  string
+	Cards []BoardCard
+}
+
+// A card tile on a board
+type BoardCard struct {
+	Id    string
+	Title string
+	Meta  []string // further styled lines of the tile, e.g. labels and assignees
+	Value any
+}
+
+// A full-screen kanban board. Columns are reloaded periodically, and cards can be moved between them.
+type Board struct {
+	Title string
+	// Loads the columns and their cards. Called in the background.
+	Load func() ([]BoardColumn, error)
+	// Moves a card to the bottom of a column, which already lists it last. Called in the background.
+	Move func(card BoardCard, column BoardColumn) error
+	// Renders the details of a card for the given width. Called in the background.
+	Details func(card BoardCard, width int) string
+	// How often the columns are reloaded, never if zero
+	Refresh time.Duration
+}
+
+// Columns are never narrower than this, the board scrolls horizontally instead
+const minBoardColumnWidth = 24
+
+type boardLoad struct {
+	columns    []BoardColumn
+	err        error
+	generation int
+}
+
+type boardDetails struct {
+	id   string
+	text string
+}
+
+type boardState struct {
+	board     *Board
+	columns   []BoardColumn
+	column    int
+	rows      map[int]int // selected card per column
+	offsets   map[int]int // first visible card per column
+	colOffset int
+	loading   bool
+	updated   time.Time
+	message   string
+
+	// Loads are only shown if no move was in flight or finished since they started
+	generation    int
+	moving        int
+	reloadPending bool
+
+	details       *BoardCard
+	detailsText   string
+	detailsScroll int
+}
+
+// Shows the board until the user quits with q, escape or ctrl+c
+func (b *Board) Run() error {
+	t, err := Open()
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	s := boardState{board: b, rows: make(map[int]int), offsets: make(map[int]int)}
+	loads := make(chan boardLoad, 1)
+	moves := make(chan error, 1)
+	details := make(chan boardDetails, 1)
+
+	reload := func() {
+		generation, ok := s.startLoad()
+		if !ok {
+			return
+		}
+		go func() {
+			columns, err := b.Load()
+			loads <- boardLoad{columns, err, generation}
+		}()
+	}
+	reload()
+
+	var ticks <-chan time.Time
+	if b.Refresh > 0 {
+		ticker := time.NewTicker(b.Refresh)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		width, height := t.Size()
+		t.Draw(s.render(width, height))
+
+		key, pressed, err := t.ReadKey(pollInterval)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case result := <-loads:
+			if s.finishLoad(result) {
+				reload()
+			}
+		case err := <-moves:
+			s.finishMove()
+			if err != nil {
+				s.message = err.Error()
+			}
+			reload()
+		case result := <-details:
+			if s.details != nil && s.details.Id == result.id {
+				s.detailsText = result.text
+			}
+		case <-ticks:
+			reload()
+		default:
+		}
+
+		if !pressed {
+			continue
+		}
+
+		if s.details != nil {
+			switch {
+			case key.Name == KeyEscape || key.Name == KeyEnter || key.Rune == 'q':
+				s.details = nil
+			case key.Name == KeyCtrlC:
+				return nil
+			case key.Name == KeyUp || key.Rune == 'k':
+				s.detailsScroll = maxInt(s.detailsScroll-1, 0)
+			case key.Name == KeyDown || key.Rune == 'j':
+				s.detailsScroll++
+			case key.Name == KeyPageUp:
+				s.detailsScroll = maxInt(s.detailsScroll-(height-2), 0)
+			case key.Name == KeyPageDown || key.Rune == ' ':
+				s.detailsScroll += height - 2
+			}
+			continue
+		}
+
+		s.message = ""
+
+		switch {
+		case key.Name == KeyCtrlC || key.Name == KeyEscape || key.Rune == 'q':
+			return nil
+		case key.Name == KeyLeft || key.Rune == 'h':
+			s.column = maxInt(s.column-1, 0)
+		case key.Name == KeyRight || key.Rune == 'l':
+			s.column = clamp(s.column+1, 0, len(s.columns)-1)
+		case key.Name == KeyUp || key.Rune == 'k':
+			s.rows[s.column] = maxInt(s.rows[s.column]-1, 0)
+		case key.Name == KeyDown || key.Rune == 'j':
+			if column, ok := s.currentColumn(); ok {
+				s.rows[s.column] = clamp(s.rows[s.column]+1, 0, len(column.Cards)-1)
+			}
+		case key.Rune == 'r':
+			reload()
+		case key.Rune == '<' || key.Rune == 'H' || key.Rune == '>' || key.Rune == 'L':
+			to := s.column + 1
+			if key.Rune == '<' || key.Rune == 'H' {
+				to = s.column - 1
+			}
+
+			if card, ok := s.moveCard(to); ok {
+				s.message = fmt.Sprintf("Moving %s to %s...", card.Title, s.columns[to].Name)
+				s.moving++
+				go func(card BoardCard, column BoardColumn) {
+					moves <- b.Move(card, column)
+				}(card, s.columns[to])
+			}
+		case key.Name == KeyEnter:
+			if card, ok := s.selectedCard(); ok && b.Details != nil {
+				s.details, s.detailsText, s.detailsScroll = &card, "", 0
+				go func(card BoardCard, width int) {
+					details <- boardDetails{card.Id, renderSafely(func() string { return b.Details(card, width) })}
+				}(card, width)
+			}
+		}
+	}
+}
+
+func (s *boardState) currentColumn() (BoardColumn, bool) {
+	if s.column >= len(s.columns) {
+		return BoardColumn{}, false
+	}
+	return s.columns[s.column], true
+}
+
+func (s *boardState) selectedCard() (BoardCard, bool) {
+	column, ok := s.currentColumn()
+	if !ok || s.rows[s.column] >= len(column.Cards) {
+		return BoardCard{}, false
+	}
+	return column.Cards[s.rows[s.column]], true
+}
+
+// Starts a load unless one is in flight, in which case another one follows it
+func (s *boardState) startLoad() (int, bool) {
+	if s.loading {
+		s.reloadPending = true
+		return 0, false
+	}
+	s.loading = true
+	return s.generation, true
+}
+
+// Shows the result of a load unless it is stale, and tells whether to load again
+func (s *boardState) finishLoad(result boardLoad) bool {
+	s.loading = false
+	if result.err != nil {
+		s.message = result.err.Error()
+	} else if s.moving == 0 && result.generation == s.generation {
+		s.setColumns(result.columns)
+	}
+
+	reload := s.reloadPending
+	s.reloadPending = false
+	return reload
+}
+
+// Marks a move as saved, so loads started before it are stale
+func (s *boardState) finishMove() {
+	s.moving = maxInt(s.moving-1, 0)
+	s.generation++
+}
+
+// Replaces the columns, keeping the selected card selected if it still exists
+func (s *boardState) setColumns(columns []BoardColumn) {
+	selected, hasSelected := s.selectedCard()
+
+	s.columns = columns
+	s.updated = time.Now()
+	s.column = clamp(s.column, 0, len(columns)-1)
+
+	for c, column := range columns {
+		s.rows[c] = clamp(s.rows[c], 0, len(column.Cards)-1)
+
+		if hasSelected {
+			for r, card := range column.Cards {
+				if card.Id == selected.Id {
+					s.column, s.rows[c] = c, r
+				}
+			}
+		}
+	}
+}
+
+// Moves the selected card to the bottom of another column right away, before the move is saved
+func (s *boardState) moveCard(to int) (BoardCard, bool) {
+	card, ok := s.selectedCard()
+	if !ok || to < 0 || to >= len(s.columns) {
+		return card, false
+	}
+
+	from := &s.columns[s.column]
+	row := s.rows[s.column]
+	from.Cards = append(from.Cards[:row:row], from.Cards[row+1:]...)
+	s.rows[s.column] = clamp(row, 0, len(from.Cards)-1)
+
+	s.columns[to].Cards = append(s.columns[to].Cards, card)
+	s.column = to
+	s.rows[to] = len(s.columns[to].Cards) - 1
+
+	return card, true
+}
+
+func (s *boardState) render(width, height int) []string {
+	if s.details != nil {
+		return s.renderDetails(width, height)
+	}
+
+	status := "updated " + s.updated.Format("15:04:05")
+	if s.loading {
+		status = "Loading..."
+	}
+	lines := []string{Bold(s.board.Title).String() + "  " + Gray(14, status).String()}
+
+	help := "←/→ column · ↑/↓ card · </> move card · enter details · r refresh · q quit"
+	if s.message != "" {
+		help = s.message
+	}
+
+	if len(s.columns) == 0 {
+		return append(lines, "", Gray(14, help).String())
+	}
+
+	// Columns share the width, down to a minimum, and the board scrolls to the selected column
+	visible := clamp((width+1)/(minBoardColumnWidth+1), 1, len(s.columns))
+	columnWidth := (width - (visible - 1)) / visible
+
+	if s.column < s.colOffset {
+		s.colOffset = s.column
+	} else if s.column >= s.colOffset+visible {
+		s.colOffset = s.column - visible + 1
+	}
+	shown := s.columns[s.colOffset:minInt(s.colOffset+visible, len(s.columns))]
+
+	separator := Gray(8, "│").String()
+	rules := make([]string, len(shown))
+	headers := make([]string, len(shown))
+	for i, column := range shown {
+		header := fmt.Sprintf(" %s (%d)", column.Name, len(column.Cards))
+		if s.colOffset+i == s.column {
+			headers[i] = Reverse(Bold(Fit(header, columnWidth))).String()
+		} else {
+			headers[i] = Bold(Fit(header, columnWidth)).String()
+		}
+		rules[i] = Gray(8, strings.Repeat("─", columnWidth)).String()
+	}
+	lines = append(lines, strings.Join(headers, separator), strings.Join(rules, Gray(8, "┼").String()))
+
+	areaHeight := maxInt(height-len(lines)-1, 1)
+	tiles := make([][]string, len(shown))
+	for i := range shown {
+		tiles[i] = s.renderColumn(s.colOffset+i, columnWidth, areaHeight)
+	}
+
+	for row := 0; row < areaHeight; row++ {
+		cells := make([]string, len(shown))
+		for i := range shown {
+			var cell string
+			if row < len(tiles[i]) {
+				cell = tiles[i][row]
+			}
+			cells[i] = Fit(cell, columnWidth)
+		}
+		lines = append(lines, strings.Join(cells, separator))
+	}
+
+	return append(lines, Gray(14, help).String())
+}
+
+// Renders the visible tiles of a column, scrolled to its selected card
+func (s *boardState) renderColumn(c, width, height int) []string {
+	column := s.columns[c]
+	selected := s.rows[c]
+
+	tiles := make([][]string, len(column.Cards))
+	for i, card := range column.Cards {
+		marker := " "
+		if c == s.column && i == selected {
+			marker = Cyan("▌").String()
+		}
+
+		title := card.Title
+		if c == s.column && i == selected {
+			title = Bold(title).String()
+		}
+
+		tile := []string{marker + Truncate(title, width-1)}
+		for _, meta := range card.Meta {
+			tile = append(tile, marker+Truncate(meta, width-1))
+		}
+		tiles[i] = append(tile, "")
+	}
+
+	offset := clamp(s.offsets[c], 0, maxInt(len(tiles)-1, 0))
+	if selected < offset {
+		offset = selected
+	}
+	for offset < selected && tileHeight(tiles[offset:selected+1]) > height {
+		offset++
+	}
+	s.offsets[c] = offset
+
+	var lines []string
+	for _, tile := range tiles[offset:] {
+		lines = append(lines, tile...)
+		if len(lines) >= height {
+			break
+		}
+	}
+	return lines
+}
+
+func tileHeight(tiles [][]string) int {
+	height := 0
+	for _, tile := range tiles {
+		height += len(tile)
+	}
+	return height
+}
+
+func (s *boardState) renderDetails(width, height int) []string {
+	lines := []string{Bold(s.details.Title).String()}
+
+	text := s.detailsText
+	if text == "" {
+		text = Gray(14, "Loading...").String()
+	}
+
+	content := strings.Split(text, "\n")
+	s.detailsScroll = clamp(s.detailsScroll, 0, maxInt(len(content)-(height-2), 0))
+
+	end := minInt(s.detailsScroll+height-2, len(content))
+	lines = append(lines, content[s.detailsScroll:end]...)
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	return append(lines, Gray(14, "↑/↓ scroll · esc back").String())
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A fuzzy match of a pattern in a text
+type Match struct {
+	Index     int   // index of the matched item
+	Score     int   // higher is better
+	Positions []int // rune positions of the matched characters
+}
+
+// Matches every space separated word of a pattern, case-insensitively, as a subsequence of the text.
+// Matches score higher for consecutive characters, characters at the start of words, and whole substrings.
+func FuzzyMatch(pattern, text string) (Match, bool) {
+	original := []rune(text)
+	runes := make([]rune, len(original))
+	for i, r := range original {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	var match Match
+	for _, word := range strings.Fields(strings.ToLower(pattern)) {
+		score, positions, ok := matchWord([]rune(word), runes, original)
+		if !ok {
+			return Match{}, false
+		}
+		if strings.Contains(string(runes), word) {
+			score += 2 * len(word)
+		}
+
+		match.Score += score
+		match.Positions = append(match.Positions, positions...)
+	}
+
+	sort.Ints(match.Positions)
+	return match, true
+}
+
+// Matches the characters of a word in order, preferring characters at the start of words in the text
+func matchWord(word, runes, original []rune) (int, []int, bool) {
+	var positions []int
+	score := 0
+	last := -2
+
+	for i, w := 0, 0; w < len(word); w++ {
+		next := indexRune(runes, word[w], i)
+		if next < 0 {
+			return 0, nil, false
+		}
+
+		// Skip ahead to a word start, if the rest of the word still matches after it
+		if next != last+1 && !isWordStart(original, next) {
+			for j := next + 1; j < len(runes); j++ {
+				if runes[j] == word[w] && isWordStart(original, j) && isSubsequence(word[w+1:], runes[j+1:]) {
+					next = j
+					break
+				}
+			}
+		}
+
+		score++
+		if next == last+1 {
+			score += 4
+		}
+		if isWordStart(original, next) {
+			score += 6
+		}
+
+		positions = append(positions, next)
+		last = next
+		i = next + 1
+	}
+
+	return score, positions, true
+}
+
+func isWordStart(runes []rune, i int) bool {
+	return i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1])
+}
+
+func indexRune(runes []rune, r rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func isSubsequence(word, runes []rune) bool {
+	i := 0
+	for w := 0; w < len(word); w++ {
+		if i = indexRune(runes, word[w], i); i < 0 {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// Fuzzy matches a pattern against several texts, returning the matches best first. Without a pattern,
+// every text matches, in order.
+func FuzzyFilter(pattern string, texts []string) []Match {
+	matches := make([]Match, 0, len(texts))
+
+	for i, text := range texts {
+		if match, ok := FuzzyMatch(pattern, text); ok {
+			match.Index = i
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
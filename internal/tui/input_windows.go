@@ -0,0 +1,14 @@
+//go:build windows
+
+package tui
+
+import (
+	"os"
+	"time"
+)
+
+// The Windows console cannot be polled, so reads block until the next key press, and background
+// updates are only shown after it
+func waitForInput(f *os.File, timeout time.Duration) bool {
+	return true
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"unicode/utf8"
+)
+
+// A key press. Printable characters have the name `rune`.
+type Key struct {
+	Name string
+	Rune rune
+}
+
+const (
+	KeyRune      = "rune"
+	KeyUp        = "up"
+	KeyDown      = "down"
+	KeyLeft      = "left"
+	KeyRight     = "right"
+	KeyHome      = "home"
+	KeyEnd       = "end"
+	KeyPageUp    = "pgup"
+	KeyPageDown  = "pgdown"
+	KeyEnter     = "enter"
+	KeyEscape    = "esc"
+	KeyBackspace = "backspace"
+	KeyTab       = "tab"
+	KeyShiftTab  = "shift+tab"
+	KeyCtrlC     = "ctrl+c"
+	KeyCtrlN     = "ctrl+n"
+	KeyCtrlP     = "ctrl+p"
+	KeyCtrlU     = "ctrl+u"
+)
+
+var escapeSequences = map[string]string{
+	"[A": KeyUp, "OA": KeyUp,
+	"[B": KeyDown, "OB": KeyDown,
+	"[C": KeyRight, "OC": KeyRight,
+	"[D": KeyLeft, "OD": KeyLeft,
+	"[H": KeyHome, "OH": KeyHome, "[1~": KeyHome, "[7~": KeyHome,
+	"[F": KeyEnd, "OF": KeyEnd, "[4~": KeyEnd, "[8~": KeyEnd,
+	"[5~": KeyPageUp,
+	"[6~": KeyPageDown,
+	"[Z":  KeyShiftTab,
+}
+
+var controlKeys = map[byte]string{
+	3:   KeyCtrlC,
+	9:   KeyTab,
+	10:  KeyEnter,
+	13:  KeyEnter,
+	14:  KeyCtrlN,
+	16:  KeyCtrlP,
+	21:  KeyCtrlU,
+	8:   KeyBackspace,
+	127: KeyBackspace,
+}
+
+// Parses the bytes read from a raw mode terminal into key presses. Unknown sequences are dropped.
+func parseKeys(input []byte) []Key {
+	var keys []Key
+
+	for len(input) > 0 {
+		c := input[0]
+
+		switch {
+		case c == 27:
+			if len(input) == 1 {
+				return append(keys, Key{Name: KeyEscape})
+			}
+
+			// Escape sequences end with a letter or `~`
+			end := 1
+			for end < len(input) && end < 8 {
+				b := input[end]
+				end++
+				if end > 2 && (b == '~' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z') {
+					break
+				}
+			}
+
+			if name, ok := escapeSequences[string(input[1:end])]; ok {
+				keys = append(keys, Key{Name: name})
+			} else if input[1] != '[' && input[1] != 'O' {
+				// Alt+key, or escape followed by another key
+				keys = append(keys, Key{Name: KeyEscape})
+				end = 1
+			}
+			input = input[end:]
+
+		case c < 32 || c == 127:
+			if name, ok := controlKeys[c]; ok {
+				keys = append(keys, Key{Name: name})
+			}
+			input = input[1:]
+
+		default:
+			r, size := utf8.DecodeRune(input)
+			keys = append(keys, Key{Name: KeyRune, Rune: r})
+			input = input[size:]
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,320 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+// An item of a picker list
+type PickerItem struct {
+	Id    string // identifies the item for caching its preview
+	Label string
+	Value any
+}
+
+// A list of items the user can switch to, e.g. "My cards" and "All cards"
+type PickerSource struct {
+	Name string
+	Load func() ([]PickerItem, error)
+}
+
+// A full-screen fuzzy finder over a list of items, with a preview of the highlighted item
+type Picker struct {
+	Prompt  string
+	Sources []PickerSource
+	// Renders the preview of an item for the given width. Called in the background.
+	Preview func(item PickerItem, width int) string
+}
+
+type loaded struct {
+	source int
+	items  []PickerItem
+	err    error
+}
+
+type preview struct {
+	id   string
+	text string
+}
+
+// How long the picker waits for a key press before checking for loaded items and previews
+const pollInterval = 50 * time.Millisecond
+
+// Previews narrower than this are hidden, leaving the whole width to the list
+const minPreviewWidth = 20
+
+// Shows the picker until the user selects an item, or cancels with escape or ctrl+c (`ErrCanceled`)
+func (p *Picker) Run() (PickerItem, error) {
+	t, err := Open()
+	if err != nil {
+		return PickerItem{}, err
+	}
+	defer t.Close()
+
+	s := pickerState{picker: p, previews: make(map[string]string), loading: true}
+	loads := make(chan loaded, len(p.Sources))
+	previews := make(chan preview, 8)
+
+	load := func(source int) {
+		s.loading = true
+		go func() {
+			items, err := p.Sources[source].Load()
+			loads <- loaded{source, items, err}
+		}()
+	}
+	load(0)
+
+	requested := ""
+	for {
+		width, height := t.Size()
+
+		// Previews are rendered for the highlighted item only, once it stays highlighted
+		if item, ok := s.selected(); ok && p.hasPreview(width) && requested != item.Id {
+			if _, cached := s.previews[item.Id]; !cached {
+				requested = item.Id
+				go func(item PickerItem, width int) {
+					previews <- preview{item.Id, renderSafely(func() string { return p.Preview(item, width) })}
+				}(item, previewWidth(width))
+			}
+		}
+
+		t.Draw(s.render(width, height))
+
+		key, pressed, err := t.ReadKey(pollInterval)
+		if err != nil {
+			return PickerItem{}, err
+		}
+
+		select {
+		case result := <-loads:
+			if result.source == s.source {
+				if result.err != nil {
+					return PickerItem{}, result.err
+				}
+				s.setItems(result.items)
+			}
+		case result := <-previews:
+			s.previews[result.id] = result.text
+		default:
+		}
+
+		if !pressed {
+			continue
+		}
+
+		switch key.Name {
+		case KeyCtrlC, KeyEscape:
+			return PickerItem{}, ErrCanceled
+		case KeyEnter:
+			if item, ok := s.selected(); ok {
+				return item, nil
+			}
+		case KeyUp, KeyCtrlP:
+			s.move(-1, height)
+		case KeyDown, KeyCtrlN:
+			s.move(1, height)
+		case KeyPageUp:
+			s.move(-listHeight(height), height)
+		case KeyPageDown:
+			s.move(listHeight(height), height)
+		case KeyTab:
+			if len(p.Sources) > 1 {
+				s.source = (s.source + 1) % len(p.Sources)
+				s.setItems(nil)
+				load(s.source)
+			}
+		case KeyBackspace:
+			if runes := []rune(s.query); len(runes) > 0 {
+				s.setQuery(string(runes[:len(runes)-1]))
+			}
+		case KeyCtrlU:
+			s.setQuery("")
+		case KeyRune:
+			s.setQuery(s.query + string(key.Rune))
+		}
+	}
+}
+
+type pickerState struct {
+	picker   *Picker
+	source   int
+	items    []PickerItem
+	matches  []Match
+	query    string
+	cursor   int
+	offset   int
+	loading  bool
+	previews map[string]string
+}
+
+func (s *pickerState) setItems(items []PickerItem) {
+	s.items = items
+	s.loading = false
+	s.setQuery(s.query)
+}
+
+func (s *pickerState) setQuery(query string) {
+	s.query = query
+
+	labels := make([]string, len(s.items))
+	for i, item := range s.items {
+		labels[i] = ansi.Strip(item.Label)
+	}
+
+	s.matches = FuzzyFilter(query, labels)
+	s.cursor, s.offset = 0, 0
+}
+
+func (s *pickerState) selected() (PickerItem, bool) {
+	if s.cursor >= len(s.matches) {
+		return PickerItem{}, false
+	}
+	return s.items[s.matches[s.cursor].Index], true
+}
+
+func (s *pickerState) move(delta, height int) {
+	s.cursor = clamp(s.cursor+delta, 0, len(s.matches)-1)
+
+	rows := listHeight(height)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	} else if s.cursor >= s.offset+rows {
+		s.offset = s.cursor - rows + 1
+	}
+}
+
+// Rows of the screen used by the list, below the prompt and status lines and above the help line
+func listHeight(height int) int {
+	return maxInt(height-3, 1)
+}
+
+func listWidth(width int) int {
+	return clamp(width*2/5, minInt(30, width), 60)
+}
+
+func previewWidth(width int) int {
+	return width - listWidth(width) - 3
+}
+
+func (p *Picker) hasPreview(width int) bool {
+	return p.Preview != nil && previewWidth(width) >= minPreviewWidth
+}
+
+// Renders text in the background, where a panic would otherwise leave the terminal in raw mode
+func renderSafely(render func() string) (text string) {
+	defer func() {
+		if r := recover(); r != nil {
+			text = fmt.Sprintf("Could not render: %v", r)
+		}
+	}()
+	return render()
+}
+
+func (s *pickerState) render(width, height int) []string {
+	p := s.picker
+	lines := []string{Bold(p.Prompt).String() + " " + s.query + Reverse(" ").String()}
+
+	status := fmt.Sprintf("%d/%d", len(s.matches), len(s.items))
+	if s.loading {
+		status = "Loading..."
+	}
+	if len(p.Sources) > 1 {
+		status = p.Sources[s.source].Name + "  " + status
+	}
+	lines = append(lines, Gray(14, status).String())
+
+	left := width
+	if p.hasPreview(width) {
+		left = listWidth(width)
+	}
+
+	var previewLines []string
+	if item, ok := s.selected(); ok && p.hasPreview(width) {
+		text, loaded := s.previews[item.Id]
+		if !loaded {
+			text = Gray(14, "Loading...").String()
+		}
+		previewLines = strings.Split(text, "\n")
+	}
+
+	rows := listHeight(height)
+	for row := 0; row < rows; row++ {
+		var line string
+		if i := s.offset + row; i < len(s.matches) {
+			line = s.renderItem(s.matches[i], left, i == s.cursor)
+		}
+		line = Fit(line, left)
+
+		if p.hasPreview(width) {
+			line += Gray(8, " │ ").String()
+			if row < len(previewLines) {
+				line += Truncate(previewLines[row], previewWidth(width))
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	help := "↑/↓ move · enter select · esc cancel"
+	if len(p.Sources) > 1 {
+		help += " · tab " + p.Sources[(s.source+1)%len(p.Sources)].Name
+	}
+	return append(lines, Gray(14, help).String())
+}
+
+// Renders a list item with its matched characters highlighted
+func (s *pickerState) renderItem(match Match, width int, selected bool) string {
+	label := []rune(ansi.Strip(s.items[match.Index].Label))
+
+	var line strings.Builder
+	if selected {
+		line.WriteString(Bold(Cyan("> ")).String())
+	} else {
+		line.WriteString("  ")
+	}
+
+	positions := make(map[int]bool, len(match.Positions))
+	for _, position := range match.Positions {
+		positions[position] = true
+	}
+
+	for i, r := range label {
+		switch {
+		case positions[i]:
+			line.WriteString(Bold(Yellow(string(r))).String())
+		case selected:
+			line.WriteString(Bold(string(r)).String())
+		default:
+			line.WriteRune(r)
+		}
+	}
+
+	return Truncate(line.String(), width)
+}
+
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,103 @@
+// Package tui implements the full-screen terminal interfaces of the CLI, such as the card picker
+// and the board, on top of a raw mode terminal.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Returned when the user leaves an interface without choosing anything
+var ErrCanceled = errors.New("canceled")
+
+// A terminal in raw mode, showing a full-screen interface on the alternate screen
+type Terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+	keys  []Key
+}
+
+// Whether stdin and stdout are both terminals, so a full-screen interface can be shown
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Switches the terminal to raw mode and the alternate screen. `Close` must be called to restore it.
+func Open() (*Terminal, error) {
+	t := &Terminal{in: os.Stdin, out: os.Stdout}
+
+	state, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	t.state = state
+
+	// Alternate screen, hidden cursor
+	fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+
+	return t, nil
+}
+
+// Restores the terminal to the state before `Open`
+func (t *Terminal) Close() error {
+	fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+	return term.Restore(int(t.in.Fd()), t.state)
+}
+
+// The width and height of the terminal
+func (t *Terminal) Size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Replaces the screen content with the given lines, cut to the terminal size
+func (t *Terminal) Draw(lines []string) {
+	width, height := t.Size()
+
+	var screen strings.Builder
+	screen.WriteString("\x1b[H")
+
+	for i := 0; i < height && i < len(lines); i++ {
+		if i > 0 {
+			screen.WriteString("\r\n")
+		}
+		screen.WriteString(Truncate(lines[i], width))
+		screen.WriteString("\x1b[K")
+	}
+	screen.WriteString("\x1b[J")
+
+	fmt.Fprint(t.out, screen.String())
+}
+
+// Waits up to `timeout` for a key press. Returns false if there was none.
+func (t *Terminal) ReadKey(timeout time.Duration) (Key, bool, error) {
+	if len(t.keys) == 0 {
+		if !waitForInput(t.in, timeout) {
+			return Key{}, false, nil
+		}
+
+		buf := make([]byte, 256)
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return Key{}, false, err
+		}
+		t.keys = parseKeys(buf[:n])
+	}
+
+	if len(t.keys) == 0 {
+		return Key{}, false, nil
+	}
+
+	key := t.keys[0]
+	t.keys = t.keys[1:]
+	return key, true, nil
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+const resetStyle = "\x1b[0m"
+
+// Cuts styled text to a visible width, keeping its color escape sequences
+func Truncate(s string, width int) string {
+	if ansi.Width(s) <= width {
+		return s
+	}
+
+	var out strings.Builder
+	visible := 0
+
+	for len(s) > 0 && visible < width {
+		if loc := ansi.Pattern.FindStringIndex(s); loc != nil && loc[0] == 0 {
+			out.WriteString(s[:loc[1]])
+			s = s[loc[1]:]
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s)
+		out.WriteString(s[:size])
+		s = s[size:]
+		visible++
+	}
+
+	out.WriteString(resetStyle)
+	return out.String()
+}
+
+// Cuts or pads styled text to exactly a visible width
+func Fit(s string, width int) string {
+	s = Truncate(s, width)
+	if gap := width - ansi.Width(s); gap > 0 {
+		s += strings.Repeat(" ", gap)
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/platogo/zube-cli/internal/ansi"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	texts := []string{
+		"#12 Fix export timestamp (Done)",
+		"#7 Use matrix in github build actions (Queued)",
+		"#31 Export cards as CSV (In Progress)",
+	}
+
+	var got []int
+	for _, match := range FuzzyFilter("exp", texts) {
+		got = append(got, match.Index)
+	}
+
+	// Both exports match at a word start, the first one comes first
+	if want := []int{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if matches := FuzzyFilter("csv export", texts); len(matches) != 1 || matches[0].Index != 2 {
+		t.Errorf("every word must match, got %+v", matches)
+	}
+
+	if matches := FuzzyFilter("", texts); len(matches) != len(texts) {
+		t.Errorf("an empty pattern must match everything, got %+v", matches)
+	}
+}
+
+func TestFuzzyMatchPositions(t *testing.T) {
+	match, ok := FuzzyMatch("gba", "github build actions")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+
+	if want := []int{0, 7, 13}; !reflect.DeepEqual(match.Positions, want) {
+		t.Errorf("got positions %v, want %v", match.Positions, want)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("a\x1b[A\x1b[6~\rö\x7f\x1b"))
+	want := []Key{
+		{Name: KeyRune, Rune: 'a'},
+		{Name: KeyUp},
+		{Name: KeyPageDown},
+		{Name: KeyEnter},
+		{Name: KeyRune, Rune: 'ö'},
+		{Name: KeyBackspace},
+		{Name: KeyEscape},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	styled := "\x1b[1mbold\x1b[0m text"
+
+	if got := Truncate(styled, 6); got != "\x1b[1mbold\x1b[0m t"+resetStyle {
+		t.Errorf("got %q", got)
+	}
+
+	if got := Fit("ab", 4); got != "ab  " {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestBoardMoveCard(t *testing.T) {
+	s := boardState{rows: make(map[int]int), offsets: make(map[int]int)}
+	s.setColumns([]BoardColumn{
+		{Name: "Todo", Cards: []BoardCard{{Id: "1"}, {Id: "2"}}},
+		{Name: "Done", Cards: []BoardCard{{Id: "3"}}},
+	})
+	s.rows[0] = 1
+
+	card, ok := s.moveCard(1)
+	if !ok || card.Id != "2" {
+		t.Fatalf("moved %v, %v", card, ok)
+	}
+	if len(s.columns[0].Cards) != 1 || len(s.columns[1].Cards) != 2 || s.column != 1 || s.rows[1] != 1 {
+		t.Errorf("unexpected state %+v", s)
+	}
+
+	if _, ok := s.moveCard(2); ok {
+		t.Error("moved past the last column")
+	}
+
+	// The moved card stays selected when the columns are reloaded in a different order
+	s.setColumns([]BoardColumn{
+		{Name: "Done", Cards: []BoardCard{{Id: "2"}, {Id: "3"}}},
+		{Name: "Todo", Cards: []BoardCard{{Id: "1"}}},
+	})
+	if selected, _ := s.selectedCard(); selected.Id != "2" {
+		t.Errorf("selected %v", selected)
+	}
+}
+
+func TestBoardStaleLoad(t *testing.T) {
+	s := boardState{rows: make(map[int]int), offsets: make(map[int]int)}
+	stale := []BoardColumn{{Name: "Todo", Cards: []BoardCard{{Id: "1"}}}, {Name: "Done"}}
+	s.setColumns(stale)
+
+	// A load is in flight when the card is moved, and the move is saved before it returns
+	generation, ok := s.startLoad()
+	if !ok {
+		t.Fatal("expected the first load to start")
+	}
+	s.moveCard(1)
+	s.moving++
+	s.finishMove()
+	if _, ok := s.startLoad(); ok {
+		t.Fatal("expected the reload to wait for the load in flight")
+	}
+
+	if !s.finishLoad(boardLoad{columns: stale, generation: generation}) {
+		t.Error("expected another load to follow the stale one")
+	}
+	if len(s.columns[1].Cards) != 1 {
+		t.Errorf("the stale load undid the move: %+v", s.columns)
+	}
+
+	generation, _ = s.startLoad()
+	fresh := []BoardColumn{{Name: "Todo"}, {Name: "Done", Cards: []BoardCard{{Id: "1"}, {Id: "2"}}}}
+	if s.finishLoad(boardLoad{columns: fresh, generation: generation}) {
+		t.Error("expected no further load")
+	}
+	if len(s.columns[1].Cards) != 2 {
+		t.Errorf("expected the fresh load to be shown, got %+v", s.columns)
+	}
+}
+
+func TestPickerPreview(t *testing.T) {
+	p := &Picker{Prompt: "Card:", Preview: func(item PickerItem, width int) string { panic("too narrow") }}
+
+	if p.hasPreview(36) || !p.hasPreview(80) {
+		t.Errorf("expected the preview to be hidden at 36 columns only")
+	}
+
+	if text := renderSafely(func() string { return p.Preview(PickerItem{}, 10) }); !strings.Contains(text, "too narrow") {
+		t.Errorf("expected the panic as the preview, got %q", text)
+	}
+
+	s := pickerState{picker: p, items: []PickerItem{{Id: "1", Label: "#1 Fix login"}}, previews: make(map[string]string)}
+	s.setQuery("")
+	for _, line := range s.render(36, 10) {
+		if ansi.Width(line) > 36 || strings.Contains(line, "│") {
+			t.Errorf("expected only the list at 36 columns, got %q", line)
+		}
+	}
+
+	// Long preview lines are cut to the width of the preview
+	s.previews["1"] = "See https://zube.io/" + strings.Repeat("a", 200)
+	for _, line := range s.render(80, 10) {
+		if ansi.Width(line) > 80 {
+			t.Errorf("expected lines of at most 80 columns, got %d: %q", ansi.Width(line), line)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// Opens a URL in the browser given by $BROWSER or, without it, the default browser of the system
+func OpenBrowser(url string) error {
+	var err error
+	for _, command := range browserCommands(os.Getenv("BROWSER"), url, runtime.GOOS) {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err = cmd.Start(); err == nil {
+			return cmd.Process.Release()
+		}
+	}
+
+	if err == nil {
+		err = errors.New("no browser found")
+	}
+	return err
+}
+
+// The commands to try for opening a URL. As in other tools, $BROWSER may list several browsers separated by
+// colons, and a browser may contain `%s` where the URL goes.
+func browserCommands(browser, url, goos string) [][]string {
+	var commands [][]string
+
+	for _, entry := range strings.Split(browser, ":") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		words, err := shellquote.Split(entry)
+		if err != nil || len(words) == 0 {
+			continue
+		}
+
+		substituted := false
+		for i, word := range words {
+			if strings.Contains(word, "%s") {
+				words[i] = strings.ReplaceAll(word, "%s", url)
+				substituted = true
+			}
+		}
+		if !substituted {
+			words = append(words, url)
+		}
+		commands = append(commands, words)
+	}
+
+	if len(commands) > 0 {
+		return commands
+	}
+
+	switch goos {
+	case "darwin":
+		return [][]string{{"open", url}}
+	case "windows":
+		return [][]string{{"rundll32", "url.dll,FileProtocolHandler", url}}
+	default:
+		return [][]string{{"xdg-open", url}}
+	}
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommands(t *testing.T) {
+	url := "https://zube.io/platogo/backend/c/1"
+
+	tests := []struct {
+		browser, goos string
+		want          [][]string
+	}{
+		{"", "linux", [][]string{{"xdg-open", url}}},
+		{"", "darwin", [][]string{{"open", url}}},
+		{"firefox", "linux", [][]string{{"firefox", url}}},
+		{"w3m:google-chrome --new-window %s", "linux", [][]string{{"w3m", url}, {"google-chrome", "--new-window", url}}},
+	}
+
+	for _, test := range tests {
+		if got := browserCommands(test.browser, url, test.goos); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("browserCommands(%q, %q) = %v, want %v", test.browser, test.goos, got, test.want)
+		}
+	}
+}
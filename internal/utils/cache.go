@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Removes the cached API responses in a directory that are older than the TTL
+func PruneCache(dir string, ttl time.Duration, now time.Time) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+
+		if now.Sub(info.ModTime()) > ttl {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// A reference to a card, as given on the command line. Account and project are names or slugs, and may be empty.
+type CardRef struct {
+	Account string
+	Project string
+	Number  int
+}
+
+func (r CardRef) String() string {
+	switch {
+	case r.Account != "":
+		return fmt.Sprintf("%s/%s/%d", r.Account, r.Project, r.Number)
+	case r.Project != "":
+		return fmt.Sprintf("%s/%d", r.Project, r.Number)
+	}
+	return fmt.Sprintf("#%d", r.Number)
+}
+
+// Parses a card reference: `123`, `#123`, `Project/123`, `Project#123`, `account/project/123`,
+// or a card URL like `https://zube.io/account/project/c/123`
+func ParseCardRef(value string) (CardRef, error) {
+	value = strings.TrimSpace(value)
+	invalid := fmt.Errorf("invalid card %q, expected a number, #number, project/number or a Zube card URL", value)
+
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil {
+			return CardRef{}, invalid
+		}
+
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(segments) != 4 || segments[2] != "c" {
+			return CardRef{}, invalid
+		}
+
+		number, err := parseCardNumber(segments[3])
+		if err != nil {
+			return CardRef{}, invalid
+		}
+		return CardRef{Account: segments[0], Project: segments[1], Number: number}, nil
+	}
+
+	var ref CardRef
+	rest := value
+	if i := strings.LastIndexAny(value, "/#"); i >= 0 {
+		ref.Project, rest = value[:i], value[i+1:]
+		if i := strings.Index(ref.Project, "/"); i >= 0 {
+			ref.Account, ref.Project = ref.Project[:i], ref.Project[i+1:]
+		}
+	}
+
+	number, err := parseCardNumber(rest)
+	if err != nil || strings.Contains(ref.Project, "/") || (ref.Account != "" && ref.Project == "") {
+		return CardRef{}, invalid
+	}
+	ref.Number = number
+
+	return ref, nil
+}
+
+func parseCardNumber(value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid card number %q", value)
+	}
+	return number, nil
+}
@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestParseCardRef(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CardRef
+		wantErr bool
+	}{
+		{"123", CardRef{Number: 123}, false},
+		{"#123", CardRef{Number: 123}, false},
+		{"Backend/123", CardRef{Project: "Backend", Number: 123}, false},
+		{"Mobile App#7", CardRef{Project: "Mobile App", Number: 7}, false},
+		{"platogo/backend/123", CardRef{Account: "platogo", Project: "backend", Number: 123}, false},
+		{"https://zube.io/platogo/backend/c/123", CardRef{Account: "platogo", Project: "backend", Number: 123}, false},
+		{"https://zube.io/platogo/backend/c/123?tab=comments", CardRef{Account: "platogo", Project: "backend", Number: 123}, false},
+		{"https://zube.io/platogo/backend/w/dev/kanban", CardRef{}, true},
+		{"a/b/c/123", CardRef{}, true},
+		{"Backend/", CardRef{}, true},
+		{"#0", CardRef{}, true},
+		{"abc", CardRef{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCardRef(test.value)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseCardRef(%q) error = %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseCardRef(%q) = %+v, want %+v", test.value, got, test.want)
+		}
+	}
+}
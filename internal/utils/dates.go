@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeDurationPattern = regexp.MustCompile(`^(\d+)\s*(h|d|w|mo|y)$`)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Parses an absolute or relative date, relative to `now`. Accepts dates (`2026-01-01`), timestamps (RFC 3339),
+// durations ago (`12h`, `7d`, `2w`, `3mo`, `1y`), `today`, `yesterday` and weekday names, which mean the
+// start of the most recent such day, today included.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if weekday, ok := weekdays[value]; ok {
+		daysAgo := (int(today.Weekday()) - int(weekday) + 7) % 7
+		return today.AddDate(0, 0, -daysAgo), nil
+	}
+
+	if match := relativeDurationPattern.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+
+		switch match[2] {
+		case "h":
+			return now.Add(-time.Duration(n) * time.Hour), nil
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		case "mo":
+			return now.AddDate(0, -n, 0), nil
+		case "y":
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return timestamp, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, use e.g. 2026-01-01, 7d, 2w, 3mo, yesterday or monday", s)
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 3, 18, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-01-01", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-01T10:00:00Z", time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{"7d", time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)},
+		{"12h", time.Date(2026, 3, 18, 3, 30, 0, 0, time.UTC)},
+		{"1mo", time.Date(2026, 2, 18, 15, 30, 0, 0, time.UTC)},
+		{"today", time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"Monday", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"wednesday", time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"thursday", time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDate(tt.value, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseDate("last tuesday", now); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
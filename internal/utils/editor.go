@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// Opens a file in the editor given by $VISUAL or $EDITOR, and waits for it to close
+func EditFile(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	words, err := shellquote.Split(editor)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(words[0], append(words[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/pflag"
+)
+
+// A client-side card filter, for conditions the Zube API cannot express
+type CardPredicate func(card *models.Card) bool
+
+// Returns the cards matching all predicates. Nil predicates are ignored.
+func FilterCards(cards []models.Card, predicates ...CardPredicate) []models.Card {
+	var active []CardPredicate
+	for _, predicate := range predicates {
+		if predicate != nil {
+			active = append(active, predicate)
+		}
+	}
+
+	if len(active) == 0 {
+		return cards
+	}
+
+	filtered := make([]models.Card, 0, len(cards))
+	for i := range cards {
+		matches := true
+		for _, predicate := range active {
+			if !predicate(&cards[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, cards[i])
+		}
+	}
+
+	return filtered
+}
+
+// Combines several predicates into one that requires all of them. Returns nil if there are none.
+func AllOf(predicates ...CardPredicate) CardPredicate {
+	var active []CardPredicate
+	for _, predicate := range predicates {
+		if predicate != nil {
+			active = append(active, predicate)
+		}
+	}
+
+	if len(active) == 0 {
+		return nil
+	}
+
+	return func(card *models.Card) bool {
+		for _, predicate := range active {
+			if !predicate(card) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Flags filtering cards by a date range, mapped to the Zube API card timestamps they compare against
+var cardDateFlags = map[string]string{
+	"created-since":  "created_at",
+	"created-before": "created_at",
+	"updated-since":  "updated_at",
+	"updated-before": "updated_at",
+	"closed-since":   "closed_at",
+	"closed-before":  "closed_at",
+}
+
+// Returns a predicate for the filter flags the Zube API cannot express: several `--status` values,
+// `--not-label` and the date range flags. Returns nil if none of them are given.
+func CardFilterFromFlags(flags *pflag.FlagSet, now time.Time) (CardPredicate, error) {
+	var predicates []CardPredicate
+
+	if statuses, _ := flags.GetStringSlice("status"); len(statuses) > 1 {
+		predicates = append(predicates, func(card *models.Card) bool {
+			return lo.ContainsBy(statuses, func(status string) bool {
+				return TitleCaseToSnakeCase(status) == card.Status
+			})
+		})
+	}
+
+	if excluded, _ := flags.GetStringSlice("not-label"); len(excluded) > 0 {
+		predicates = append(predicates, func(card *models.Card) bool {
+			return !lo.SomeBy(newCardRecord(card).Values("label"), func(label string) bool {
+				return lo.ContainsBy(excluded, func(name string) bool { return strings.EqualFold(name, label) })
+			})
+		})
+	}
+
+	dateFlags := lo.Keys(cardDateFlags)
+	sort.Strings(dateFlags)
+
+	for _, flag := range dateFlags {
+		value, _ := flags.GetString(flag)
+		if value == "" {
+			continue
+		}
+
+		date, err := ParseDate(value, now)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", flag, err)
+		}
+
+		field, since := cardDateFlags[flag], strings.HasSuffix(flag, "-since")
+
+		predicates = append(predicates, func(card *models.Card) bool {
+			timestamp, ok := newCardRecord(card).fields[field].(string)
+			if !ok {
+				return false
+			}
+
+			t, err := time.Parse(time.RFC3339, timestamp)
+			if err != nil {
+				return false
+			}
+
+			if since {
+				return !t.Before(date)
+			}
+			return t.Before(date)
+		})
+	}
+
+	return AllOf(predicates...), nil
+}
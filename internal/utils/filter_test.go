@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/platogo/zube/models"
+	"github.com/spf13/pflag"
+)
+
+func TestCardFilterFromFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("status", nil, "")
+	flags.StringSlice("not-label", nil, "")
+	flags.String("updated-since", "", "")
+
+	flags.Set("status", "queued,In Progress")
+	flags.Set("not-label", "WontFix")
+
+	cards := []models.Card{
+		{Number: 1, Status: "queued"},
+		{Number: 2, Status: "in_progress", Labels: []models.Label{{Name: "wontfix"}}},
+		{Number: 3, Status: "in_progress", Labels: []models.Label{{Name: "bug"}}},
+		{Number: 4, Status: "done"},
+	}
+
+	predicate, err := CardFilterFromFlags(flags, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cardNumbers(FilterCards(cards, predicate)); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("got cards %v, want [1 3]", got)
+	}
+
+	flags.Set("updated-since", "someday")
+	if _, err := CardFilterFromFlags(flags, time.Now()); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// How a name given by the user is matched against the names of items
+type NameMatch int
+
+const (
+	// Accepts a unique partial match of the name, which is convenient for read-only filters
+	PartialMatch NameMatch = iota
+	// Requires the whole case-insensitive name, so commands that change cards never act on a guess
+	ExactMatch
+)
+
+// Finds an item either by its numeric ID or by its case-insensitive name.
+// A unique partial match is accepted as well, while ambiguous names result in an error listing all candidates.
+// `kind` is only used in error messages, e.g. "project".
+func FindByNameOrId[T any](kind, value string, items []T, id func(T) int, name func(T) string) (T, error) {
+	return FindByNameOrIdMatching(PartialMatch, kind, value, items, id, name)
+}
+
+// Finds an item either by its numeric ID or by its whole case-insensitive name, for commands that change cards
+func FindExactByNameOrId[T any](kind, value string, items []T, id func(T) int, name func(T) string) (T, error) {
+	return FindByNameOrIdMatching(ExactMatch, kind, value, items, id, name)
+}
+
+// Finds an item either by its numeric ID or by its name, matched as given
+func FindByNameOrIdMatching[T any](match NameMatch, kind, value string, items []T, id func(T) int, name func(T) string) (T, error) {
+	if number, err := strconv.Atoi(value); err == nil {
+		for _, item := range items {
+			if id(item) == number {
+				return item, nil
+			}
+		}
+	}
+
+	return FindByNameMatching(match, kind, value, items, name)
+}
+
+// Finds an item by its case-insensitive name, or by a unique partial match of it.
+// Ambiguous names result in an error listing all candidates.
+func FindByName[T any](kind, value string, items []T, name func(T) string) (T, error) {
+	return FindByNameMatching(PartialMatch, kind, value, items, name)
+}
+
+// Finds an item by its name, matched as given. Ambiguous names result in an error listing all candidates.
+func FindByNameMatching[T any](match NameMatch, kind, value string, items []T, name func(T) string) (T, error) {
+	var zero T
+	var exact, partial []T
+
+	for _, item := range items {
+		switch {
+		case strings.EqualFold(name(item), value):
+			exact = append(exact, item)
+		case match == PartialMatch && strings.Contains(strings.ToLower(name(item)), strings.ToLower(value)):
+			partial = append(partial, item)
+		}
+	}
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = partial
+	}
+
+	switch len(candidates) {
+	case 0:
+		return zero, fmt.Errorf("%s %q not found", kind, value)
+	case 1:
+		return candidates[0], nil
+	}
+
+	names := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		names[i] = strconv.Quote(name(candidate))
+	}
+
+	return zero, fmt.Errorf("%s %q is ambiguous, it matches: %s", kind, value, strings.Join(names, ", "))
+}
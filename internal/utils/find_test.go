@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+type namedItem struct {
+	id   int
+	name string
+}
+
+func TestFindByNameOrId(t *testing.T) {
+	items := []namedItem{{1, "Backend"}, {2, "Frontend"}, {3, "42"}, {4, "Backoffice"}, {5, "Mobile"}, {6, "Mobile Legacy"}}
+	id := func(i namedItem) int { return i.id }
+	name := func(i namedItem) string { return i.name }
+
+	tests := []struct {
+		value   string
+		want    int
+		wantErr string
+	}{
+		{"2", 2, ""},
+		{"backend", 1, ""},
+		{"42", 3, ""},
+		{"front", 2, ""},
+		{"mobile", 5, ""},
+		{"back", 0, `project "back" is ambiguous, it matches: "Backend", "Backoffice"`},
+		{"Desktop", 0, `project "Desktop" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			res, err := FindByNameOrId("project", tt.value, items, id, name)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.id != tt.want {
+				t.Errorf("expected %d got %d", tt.want, res.id)
+			}
+		})
+	}
+}
+
+func TestFindExactByNameOrId(t *testing.T) {
+	items := []namedItem{{1, "Backend"}, {2, "Frontend"}, {5, "Mobile"}, {6, "Mobile Legacy"}}
+	id := func(i namedItem) int { return i.id }
+	name := func(i namedItem) string { return i.name }
+
+	for value, want := range map[string]int{"2": 2, "BACKEND": 1, "mobile": 5} {
+		if res, err := FindExactByNameOrId("project", value, items, id, name); err != nil || res.id != want {
+			t.Errorf("expected %q to find %d, got %d, %v", value, want, res.id, err)
+		}
+	}
+
+	for _, value := range []string{"back", "front", "Legacy"} {
+		if res, err := FindExactByNameOrId("project", value, items, id, name); err == nil {
+			t.Errorf("expected %q not to match, got %d", value, res.id)
+		}
+	}
+}
+
+func TestFindDefault(t *testing.T) {
+	items := []namedItem{{1, "Backend"}, {2, "Frontend"}}
+	id := func(i namedItem) int { return i.id }
+	name := func(i namedItem) string { return i.name }
+	t.Cleanup(viper.Reset)
+
+	viper.Set("default_project", "")
+	if _, ok, err := FindDefault("default_project", items, id, name); ok || err != nil {
+		t.Errorf("expected no default, got %v, %v", ok, err)
+	}
+
+	viper.Set("default_project", "frontend")
+	if item, ok, err := FindDefault("default_project", items, id, name); !ok || err != nil || item.id != 2 {
+		t.Errorf("expected Frontend, got %v, %v, %v", item, ok, err)
+	}
+
+	viper.Set("default_project", "Front")
+	if _, ok, err := FindDefault("default_project", items, id, name); ok || err == nil {
+		t.Errorf("expected an error for an unknown default, got %v, %v", ok, err)
+	}
+}
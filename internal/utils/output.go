@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/jq"
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported values of the global `--output` flag
+const (
+	OutputTable  = "table"
+	OutputJSON   = "json"
+	OutputYAML   = "yaml"
+	OutputCSV    = "csv"
+	OutputNDJSON = "ndjson"
+)
+
+var OutputFormats = []string{OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputNDJSON}
+
+// Turns colors on or off: "always", "never", or "auto" to only color the output of a terminal
+func ConfigureColors(mode string) {
+	enabled := mode != "never" && (mode != "auto" || term.IsTerminal(int(os.Stdout.Fd())))
+
+	aurora.DefaultColorizer = aurora.New(aurora.WithColors(enabled), aurora.WithHyperlinks(enabled))
+	color.Enable = enabled
+}
+
+// Returns the output format selected with the `--output` flag, `table` by default
+func OutputFormat() string {
+	if format := viper.GetString("output"); format != "" {
+		return format
+	}
+	return OutputTable
+}
+
+// Checks whether a machine-readable output format, a `--template` or a `--jq` filter was selected
+// instead of the default table
+func IsStructuredOutput() bool {
+	return OutputFormat() != OutputTable || viper.GetString("template") != "" || viper.GetString("jq") != ""
+}
+
+// Checks whether the selected output prints every item on its own, so that items can be printed as they arrive
+func isLineBasedOutput() bool {
+	if viper.GetString("jq") != "" {
+		return false
+	}
+	return viper.GetString("template") != "" || OutputFormat() == OutputNDJSON
+}
+
+// Validates an output format name
+func ValidateOutputFormat(format string) error {
+	if !lo.Contains(OutputFormats, format) {
+		return fmt.Errorf("unsupported output format %q, must be one of %s", format, strings.Join(OutputFormats, ", "))
+	}
+	return nil
+}
+
+// Validates the `--output`, `--template` and `--jq` flags, so mistakes are reported before any request is made
+func ValidateOutputOptions() error {
+	if err := ValidateOutputFormat(OutputFormat()); err != nil {
+		return err
+	}
+
+	tmpl, program := viper.GetString("template"), viper.GetString("jq")
+
+	if tmpl != "" && program != "" {
+		return fmt.Errorf("--template and --jq cannot be used together")
+	}
+
+	if tmpl != "" {
+		if _, err := ParseTemplate(tmpl); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	if program != "" {
+		if _, err := jq.Parse(program); err != nil {
+			return fmt.Errorf("invalid jq filter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Prints any item or slice of items to stdout, filtered by `--jq`, rendered with `--template`
+// or else in the selected structured output format
+func PrintStructured(v any) {
+	var err error
+
+	switch {
+	case viper.GetString("jq") != "":
+		err = WriteJQ(os.Stdout, viper.GetString("jq"), v)
+	case viper.GetString("template") != "":
+		err = WriteTemplate(os.Stdout, viper.GetString("template"), v)
+	case OutputFormat() == OutputTable:
+		err = WriteStructured(os.Stdout, OutputJSON, v)
+	default:
+		err = WriteStructured(os.Stdout, OutputFormat(), v)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Serializes any item or slice of items in the given structured format, using the Zube API field names
+func WriteStructured(w io.Writer, format string, v any) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(generic)
+	case OutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(generic)
+	case OutputNDJSON:
+		encoder := json.NewEncoder(w)
+		items, ok := generic.([]any)
+		if !ok {
+			return encoder.Encode(generic)
+		}
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OutputCSV:
+		return writeCSV(w, v, generic)
+	default:
+		return fmt.Errorf("unsupported structured output format %q", format)
+	}
+}
+
+// Writes one CSV row per item, with one column per top-level field. Nested values are JSON encoded.
+func writeCSV(w io.Writer, v any, generic any) error {
+	items, ok := generic.([]any)
+	if !ok {
+		items = []any{generic}
+	}
+
+	columns := csvColumns(v, items)
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		row := make([]string, len(columns))
+
+		for i, column := range columns {
+			row[i], _ = csvValue(fields[column])
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Column order follows the JSON field order of the underlying struct, any other keys are appended sorted
+func csvColumns(v any, items []any) []string {
+	columns := jsonFieldNames(reflect.TypeOf(v))
+
+	var extra []string
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		for key := range fields {
+			if !lo.Contains(columns, key) && !lo.Contains(extra, key) {
+				extra = append(extra, key)
+			}
+		}
+	}
+	sort.Strings(extra)
+
+	return append(columns, extra...)
+}
+
+func csvValue(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case map[string]any, []any:
+		data, err := json.Marshal(value)
+		return string(data), err
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// Collects the JSON field names of a struct type, following pointers, slices and embedded structs
+func jsonFieldNames(t reflect.Type) []string {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		switch {
+		case name == "-":
+			continue
+		case name == "" && field.Anonymous:
+			names = append(names, jsonFieldNames(field.Type)...)
+		case name == "":
+			names = append(names, field.Name)
+		default:
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Round-trips a value through JSON, so every format uses the same field names as the Zube API
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	return normalizeNumbers(generic), nil
+}
+
+// Converts `json.Number`s into int64 or float64, so they are not serialized as strings
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+type outputExample struct {
+	Id     int      `json:"id"`
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+func TestWriteStructured(t *testing.T) {
+	items := []outputExample{
+		{Id: 12345678, Title: "Fix export", Labels: []string{"bug"}},
+		{Id: 2, Title: "Add, CSV", Labels: nil},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{OutputNDJSON, `{"id":12345678,"labels":["bug"],"title":"Fix export"}
+{"id":2,"labels":null,"title":"Add, CSV"}
+`},
+		{OutputCSV, `id,title,labels
+12345678,Fix export,"[""bug""]"
+2,"Add, CSV",
+`},
+		{OutputYAML, `- id: 12345678
+  labels:
+    - bug
+  title: Fix export
+- id: 2
+  labels: null
+  title: Add, CSV
+`},
+		{OutputJSON, `[
+  {
+    "id": 12345678,
+    "labels": [
+      "bug"
+    ],
+    "title": "Fix export"
+  },
+  {
+    "id": 2,
+    "labels": null,
+    "title": "Add, CSV"
+  }
+]
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := WriteStructured(&buf, tt.format, &items); err != nil {
+				t.Fatal(err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	if err := ValidateOutputFormat(OutputJSON); err != nil {
+		t.Errorf("expected json to be valid, got %v", err)
+	}
+
+	if err := ValidateOutputFormat("xml"); err == nil {
+		t.Error("expected xml to be invalid")
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	items := []outputExample{
+		{Id: 1, Title: "Fix export timestamp", Labels: []string{"bug", "backend"}},
+		{Id: 2, Title: "in_progress"},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{{.Id}} {{.Title | truncate 8}}`, "1 Fix expo...\n2 in_progr...\n"},
+		{`{{.Id}}: {{join "," .Labels}}{{"\n"}}`, "1: bug,backend\n2: \n"},
+		{`{{snakeToTitle .Title}}`, "Fix Export Timestamp\nIn Progress\n"},
+		{`{{json .Labels}}`, "[\"bug\",\"backend\"]\nnull\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := WriteTemplate(&buf, tt.template, &items); err != nil {
+				t.Fatal(err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("expected %q got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteJQ(t *testing.T) {
+	items := []outputExample{{Id: 1, Title: "Fix export"}, {Id: 2, Title: "Add CSV"}}
+
+	var buf bytes.Buffer
+
+	if err := WriteJQ(&buf, `.[] | select(.id > 1) | .title, .id`, &items); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Add CSV\n2\n"; buf.String() != want {
+		t.Errorf("expected %q got %q", want, buf.String())
+	}
+}
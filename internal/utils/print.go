@@ -2,19 +2,27 @@ package utils
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
-	"unicode/utf8"
 
-	"github.com/InVisionApp/tabular"
 	"github.com/gookit/color"
 	. "github.com/logrusorgru/aurora/v4"
 	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/api"
+	"github.com/platogo/zube-cli/internal/markdown"
 	"github.com/platogo/zube/models"
 	"github.com/samber/lo"
+	"github.com/spf13/viper"
 )
 
-// PrintItems prints a slice of items in a formatted table
+// PrintItems prints a slice of items in a formatted table, or in the selected structured output format
 func PrintItems(items interface{}) {
+	if IsStructuredOutput() {
+		PrintStructured(items)
+		return
+	}
+
 	switch items := items.(type) {
 	case *[]models.Card:
 		PrintCards(items)
@@ -37,39 +45,170 @@ func PrintItems(items interface{}) {
 	}
 }
 
+// Columns of the card table, of which `--columns` can select any
+var cardColumns = []TableColumn{
+	{Key: "number", Header: "Number"},
+	{Key: "title", Header: "Title", Flex: true},
+	{Key: "status", Header: "Status"},
+	{Key: "assignees", Header: "Assignees", Flex: true},
+	{Key: "labels", Header: "Labels", Flex: true},
+	{Key: "priority", Header: "Priority"},
+	{Key: "epic", Header: "Epic"},
+}
+
+var defaultCardColumns = []string{"number", "title", "status"}
+
 func PrintCards(cards *[]models.Card) {
-	const maxTitleLen = 60
+	table := MustNewTable(cardColumns, defaultCardColumns...)
+	table.Render(cardRows(*cards))
+}
+
+func cardRows(cards []models.Card) []TableRow {
+	return lo.Map(cards, func(card models.Card, _ int) TableRow {
+		var assigneeNames []string
+		for _, assignee := range card.Assignees {
+			assigneeNames = append(assigneeNames, assignee.Username)
+		}
+
+		row := TableRow{
+			"number":    BrightGreen(card.Number).String(),
+			"title":     card.Title,
+			"status":    SnakeCaseToTitleCase(card.Status),
+			"assignees": strings.Join(assigneeNames, ", "),
+			"labels": strings.Join(lo.Map(card.Labels, func(label models.Label, _ int) string {
+				return FormatLabel(label)
+			}), " "),
+		}
 
-	tab := tabular.New()
+		if priority := card.Priority.OrElse(0); priority != 0 {
+			row["priority"] = fmt.Sprintf("P%d", priority)
+		}
+		if title, ok := epicTitles[card.EpicId]; ok {
+			row["epic"] = title
+		} else if card.EpicId != 0 {
+			row["epic"] = fmt.Sprint(card.EpicId)
+		}
+
+		return row
+	})
+}
 
-	tab.Col("no", "Number", 6)
-	tab.Col("title", "Title", maxTitleLen+6)
-	tab.Col("status", "Status", 10)
+// Whether the card table only shows columns of partial cards, as selected by `NewQueryFromFlags`
+func cardTableIsPartial() bool {
+	if viper.GetBool("wide") {
+		return false
+	}
+
+	return lo.Every(defaultCardColumns, selectedColumns())
+}
 
-	format := tab.Print("no", "title", "status")
+// Whether the card table shows a column
+func cardTableShows(column string) bool {
+	columns := selectedColumns()
+	if viper.GetBool("wide") || len(columns) == 0 && lo.Contains(defaultCardColumns, column) {
+		return true
+	}
+	return lo.Contains(columns, column)
+}
 
-	for _, card := range *cards {
+func selectedColumns() []string {
+	return lo.Map(viper.GetStringSlice("columns"), func(c string, _ int) string { return strings.ToLower(strings.TrimSpace(c)) })
+}
+
+// Titles of the epics in the epic column of card tables, by ID
+var epicTitles = make(map[int]string)
+
+// Looks up the titles of the epics of cards, if the card table shows them. Otherwise, the epic column
+// shows the epic ID.
+func LoadEpicTitles(client *zube.Client, cards []models.Card) {
+	if !cardTableShows("epic") || IsStructuredOutput() {
+		return
+	}
+
+	missing := lo.Filter(cards, func(card models.Card, _ int) bool {
+		_, ok := epicTitles[card.EpicId]
+		return card.EpicId != 0 && !ok
+	})
+	for id, title := range fetchEpicTitles(client, missing) {
+		epicTitles[id] = title
+	}
+}
+
+// Prints a card table per group, each under a heading with the group's card count, or the groups
+// in the selected structured output format
+func PrintCardGroups(groups *[]CardGroup, field string) {
+	if IsStructuredOutput() {
+		PrintStructured(groups)
+		return
+	}
+
+	for i, group := range *groups {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		name := group.Name
+		if field == "status" {
+			name = SnakeCaseToTitleCase(name)
+		}
+
+		fmt.Printf("%s %s\n\n", Bold(name), Gray(14, fmt.Sprintf("(%d)", group.Count)))
+		PrintCards(&group.Cards)
+	}
+}
+
+// Prints cards page by page, as they are fetched. Table rows and line based formats are printed right away,
+// other formats are collected and printed once all pages were written.
+type CardStream struct {
+	table     *Table
+	collected []models.Card
+}
 
-		fmtTitle := TruncateString(card.Title, maxTitleLen)
+func NewCardStream() *CardStream {
+	return &CardStream{}
+}
 
-		if utf8.RuneCountInString(card.Title) > maxTitleLen {
-			fmtTitle += "..."
+// Prints or collects a page of cards
+func (s *CardStream) Write(cards []models.Card) {
+	switch {
+	case !IsStructuredOutput():
+		if s.table == nil {
+			s.table = MustNewTable(cardColumns, defaultCardColumns...)
 		}
+		s.table.Render(cardRows(cards))
+	case isLineBasedOutput():
+		PrintStructured(&cards)
+	default:
+		s.collected = append(s.collected, cards...)
+	}
+}
 
-		fmt.Printf(format,
-			BrightGreen(card.Number),
-			fmtTitle,
-			SnakeCaseToTitleCase(card.Status),
-		)
+// Prints all collected cards, if any
+func (s *CardStream) Flush() {
+	switch {
+	case !IsStructuredOutput():
+		if s.table == nil {
+			PrintCards(&[]models.Card{})
+		}
+	case !isLineBasedOutput():
+		if s.collected == nil {
+			s.collected = []models.Card{}
+		}
+		PrintStructured(&s.collected)
 	}
 }
 
 func PrintCard(account *models.Account, project *models.Project, card *models.Card) {
+	FprintCard(os.Stdout, terminalWidth(), account, project, card)
+}
+
+// Writes the details of a card, with its body rendered for the given width
+func FprintCard(w io.Writer, width int, account *models.Account, project *models.Project, card *models.Card) {
 	var labels []string
 	var assigneeNames []string
 
 	for _, label := range card.Labels {
-		labels = append(labels, printfLabel(label))
+		labels = append(labels, FormatLabel(label))
 	}
 
 	for _, assignee := range card.Assignees {
@@ -80,120 +219,147 @@ func PrintCard(account *models.Account, project *models.Project, card *models.Ca
 
 	titleFormat := Reverse(card.Title + " #" + fmt.Sprint(card.Number)).Bold()
 	statusFormat := Underline(SnakeCaseToTitleCase(card.Status))
-	bodyFormat := Gray(22, card.Body)
 	cardUrl := zube.CardUrl(account, project, card)
 
-	fmt.Println(titleFormat)
-	fmt.Println(statusFormat)
-	fmt.Println(Bold("Assignees:"), strings.Join(assigneeNames, " "))
-	fmt.Println(Bold("Labels:"), strings.Join(labels, " "))
+	fmt.Fprintln(w, titleFormat)
+	fmt.Fprintln(w, statusFormat)
+	fmt.Fprintln(w, Bold("Assignees:"), strings.Join(assigneeNames, " "))
+	fmt.Fprintln(w, Bold("Labels:"), strings.Join(labels, " "))
 
 	if priority != 0 {
-		fmt.Println(Bold("Priority:"), fmt.Sprintf("P%d", priority))
+		fmt.Fprintln(w, Bold("Priority:"), fmt.Sprintf("P%d", priority))
 	}
 
 	if card.GithubIssue.Id != 0 {
-		fmt.Println(Bold("Github:"), fmt.Sprintf("%s#%d", card.GithubIssue.Source.Name, card.GithubIssue.Number))
+		fmt.Fprintln(w, Bold("Github:"), fmt.Sprintf("%s#%d", card.GithubIssue.Source.Name, card.GithubIssue.Number))
 	}
 
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, renderMarkdown(card.Body, width))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, Bold("View this card on Zube: "+cardUrl))
+}
+
+// Prints the cards a card blocks, is blocked by and is related to, if any
+func PrintRelations(relations []api.CardRelation) {
+	if len(relations) == 0 {
+		return
+	}
+
+	headings := []struct{ relationType, heading string }{
+		{api.RelationBlockedBy, "Blocked by:"},
+		{api.RelationBlocks, "Blocks:"},
+		{api.RelationRelated, "Related:"},
+	}
+
+	for _, heading := range headings {
+		for _, relation := range relations {
+			if relation.Type != heading.relationType {
+				continue
+			}
+
+			card := relation.Card
+			fmt.Println(Bold(heading.heading), BrightGreen(fmt.Sprintf("#%d", card.Number)), card.Title, Gray(14, "("+SnakeCaseToTitleCase(card.Status)+")"))
+		}
+	}
 	fmt.Println()
-	fmt.Println(bodyFormat)
-	fmt.Println()
-	fmt.Println(Bold("View this card on Zube: " + cardUrl))
 }
 
 func PrintComments(comments *[]models.Comment) {
+	FprintComments(os.Stdout, terminalWidth(), comments)
+}
+
+// Writes comments, with their bodies rendered for the given width
+func FprintComments(w io.Writer, width int, comments *[]models.Comment) {
 
-	fmt.Printf("------\n\n%s\n\n", Bold("Comments"))
+	fmt.Fprintf(w, "------\n\n%s\n\n", Bold("Comments"))
 
 	for _, comment := range *comments {
-		fmt.Printf("%s\n%s\n\n", Reverse(comment.Creator.Name), Gray(14, comment.Timestamps.CreatedAt))
+		fmt.Fprintf(w, "%s\n%s\n\n", Reverse(comment.Creator.Name), Gray(14, comment.Timestamps.CreatedAt))
 
-		fmt.Println(comment.Body)
+		fmt.Fprintln(w, renderMarkdown(comment.Body, width))
 	}
 }
 
 func PrintEpics(epics *[]models.Epic) {
-	tab := tabular.New()
-
-	tab.Col("id", "ID", 6)
-	tab.Col("title", "Title", 40)
-	tab.Col("status", "Status", 10)
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "title", Header: "Title", Flex: true},
+		{Key: "status", Header: "Status"},
+	})
 
-	format := tab.Print("id", "title", "status")
-	for _, epic := range *epics {
-		fmt.Printf(format, BrightMagenta(epic.Id), epic.Title, SnakeCaseToTitleCase(epic.Status))
-	}
+	table.Render(lo.Map(*epics, func(epic models.Epic, _ int) TableRow {
+		return TableRow{"id": BrightMagenta(epic.Id).String(), "title": epic.Title, "status": SnakeCaseToTitleCase(epic.Status)}
+	}))
 }
 
 func PrintProjects(projects *[]models.Project) {
-	tab := tabular.New()
-
-	tab.Col("id", "ID", 4)
-	tab.Col("name", "Name", 10)
-	tab.Col("description", "Description", 20)
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "name", Header: "Name", Flex: true},
+		{Key: "description", Header: "Description", Flex: true},
+	})
 
-	format := tab.Print("id", "name", "description")
-	for _, project := range *projects {
-		fmt.Printf(format, BrightMagenta(project.Id), project.Name, project.Description)
-	}
+	table.Render(lo.Map(*projects, func(project models.Project, _ int) TableRow {
+		return TableRow{"id": BrightMagenta(project.Id).String(), "name": project.Name, "description": project.Description}
+	}))
 }
 
 func PrintWorkspaces(workspaces *[]models.Workspace) {
-	tab := tabular.New()
-
-	tab.Col("id", "ID", 6)
-	tab.Col("name", "Name", 20)
-	tab.Col("description", "Description", 30)
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "name", Header: "Name", Flex: true},
+		{Key: "description", Header: "Description", Flex: true},
+	})
 
-	format := tab.Print("id", "name", "description")
-	for _, workspace := range *workspaces {
-		fmt.Printf(format,
-			BrightYellow(workspace.Id),
-			workspace.Name,
-			workspace.Description,
-		)
-	}
+	table.Render(lo.Map(*workspaces, func(workspace models.Workspace, _ int) TableRow {
+		return TableRow{"id": BrightYellow(workspace.Id).String(), "name": workspace.Name, "description": workspace.Description}
+	}))
 }
 
 func PrintSprints(sprints *[]models.Sprint) {
-	tab := tabular.New()
-
-	tab.Col("id", "ID", 6)
-	tab.Col("title", "Title", 20)
-	tab.Col("state", "State", 10)
-
-	format := tab.Print("id", "title", "state")
-	lo.ForEach(*sprints, func(sprint models.Sprint, _ int) {
-		fmt.Printf(format, BrightYellow(sprint.Id), sprint.Title, sprint.State)
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "title", Header: "Title", Flex: true},
+		{Key: "state", Header: "State"},
 	})
+
+	table.Render(lo.Map(*sprints, func(sprint models.Sprint, _ int) TableRow {
+		return TableRow{"id": BrightYellow(sprint.Id).String(), "title": sprint.Title, "state": sprint.State}
+	}))
 }
 
 func PrintSources(sources *[]models.Source) {
-	tab := tabular.New()
-
-	tab.Col("id", "ID", 10)
-	tab.Col("name", "Name", 30)
-
-	format := tab.Print("id", "name")
-	lo.ForEach(*sources, func(source models.Source, _ int) {
-		fmt.Printf(format, BrightYellow(source.Id), source.Name)
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "name", Header: "Name", Flex: true},
 	})
+
+	table.Render(lo.Map(*sources, func(source models.Source, _ int) TableRow {
+		return TableRow{"id": BrightYellow(source.Id).String(), "name": source.Name}
+	}))
 }
 
 func PrintLabels(labels *[]models.Label) {
-	tab := tabular.New()
+	table := MustNewTable([]TableColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "name", Header: "Name", Flex: true},
+	})
 
-	tab.Col("id", "ID", 10)
-	tab.Col("name", "Name", 30)
+	table.Render(lo.Map(*labels, func(label models.Label, _ int) TableRow {
+		return TableRow{"id": BrightYellow(label.Id).String(), "name": FormatLabel(label)}
+	}))
+}
 
-	format := tab.Print("id", "name")
-	lo.ForEach(*labels, func(label models.Label, _ int) {
-		fmt.Printf(format, BrightYellow(label.Id), printfLabel(label))
-	})
+// Renders Markdown for the terminal, unless the source was asked for with `--raw`
+func renderMarkdown(source string, width int) string {
+	if viper.GetBool("raw") {
+		return source
+	}
+	return markdown.Render(source, width)
 }
 
-func printfLabel(label models.Label) string {
+func FormatLabel(label models.Label) string {
 	hexColor := color.HEX(label.Color, false)
 	return hexColor.Sprint(label.Name)
 }
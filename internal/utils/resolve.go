@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Resolves the name-based filter flags (`--project`, `--workspace`, `--sprint`, `--epic`, `--assignee` and `--label`)
+// into the ID filters of a query, using the Zube API to look up the names. Commands that change cards pass
+// `ExactMatch`, so partial names are never accepted for them.
+func ResolveQueryNames(client *zube.Client, flags *pflag.FlagSet, query *zube.Query, match NameMatch) error {
+	where := query.Filter.Where
+	if where == nil {
+		where = make(map[string]any)
+		query.Filter.Where = where
+	}
+
+	projectId, err := resolveProjectId(client, flags, match)
+	if err != nil {
+		return err
+	}
+	if projectId != 0 {
+		where["project_id"] = projectId
+	}
+
+	workspaceId, err := resolveWorkspaceId(client, flags, match)
+	if err != nil {
+		return err
+	}
+	if workspaceId != 0 {
+		where["workspace_id"] = workspaceId
+	}
+
+	// `@current-sprint` is resolved to the open sprint of the workspace, which is mostly useful in saved views
+	if sprintName, _ := flags.GetString("sprint"); sprintName != "" {
+		if workspaceId == 0 {
+			return fmt.Errorf("--sprint requires --workspace or --workspace-id")
+		}
+
+		sprint, err := findSprint(client.FetchSprints(workspaceId), sprintName, match)
+		if err != nil {
+			return err
+		}
+		where["sprint_id"] = sprint.Id
+	}
+
+	if epicTitle, _ := flags.GetString("epic"); epicTitle != "" {
+		if projectId == 0 {
+			return fmt.Errorf("--epic requires --project or --project-id")
+		}
+
+		epics := client.FetchEpics(projectId)
+		epic, err := FindByNameMatching(match, "epic", epicTitle, epics, func(e models.Epic) string { return e.Title })
+		if err != nil {
+			return err
+		}
+		where["epic_id"] = epic.Id
+	}
+
+	if assigneeName, _ := flags.GetString("assignee"); assigneeName != "" {
+		assigneeId, err := resolveAssigneeId(client, assigneeName, projectId, match)
+		if err != nil {
+			return err
+		}
+		where["assignee_ids"] = []string{strconv.Itoa(assigneeId)}
+	}
+
+	if labelName, _ := flags.GetString("label"); labelName != "" {
+		if projectId == 0 {
+			return fmt.Errorf("--label requires --project or --project-id")
+		}
+
+		labels := client.FetchLabels(projectId)
+		label, err := FindByNameMatching(match, "label", labelName, labels, func(l models.Label) string { return l.Name })
+		if err != nil {
+			return err
+		}
+		where["label_ids"] = []string{strconv.Itoa(label.Id)}
+	}
+
+	return nil
+}
+
+// Returns the project ID given either by name with `--project`, or directly with `--project-id`, or else
+// the `default_project` setting. Returns zero if there is none.
+func ResolveProjectId(client *zube.Client, flags *pflag.FlagSet) (int, error) {
+	return resolveProjectId(client, flags, PartialMatch)
+}
+
+func resolveProjectId(client *zube.Client, flags *pflag.FlagSet, match NameMatch) (int, error) {
+	if name, _ := flags.GetString("project"); name != "" {
+		projects := client.FetchProjects(&zube.Query{})
+		project, err := FindByNameOrIdMatching(match, "project", name, projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
+		return project.Id, err
+	}
+
+	if projectId, _ := flags.GetInt("project-id"); projectId != 0 {
+		return projectId, nil
+	}
+
+	if name := defaultScope(flags, "default_project"); name != "" {
+		projects := client.FetchProjects(&zube.Query{})
+		project, err := FindByNameOrIdMatching(match, "default project", name, projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
+		return project.Id, err
+	}
+
+	return 0, nil
+}
+
+// Returns the workspace ID given either by name with `--workspace`, or directly with `--workspace-id`, or else
+// the `default_workspace` setting. Returns zero if there is none.
+func ResolveWorkspaceId(client *zube.Client, flags *pflag.FlagSet) (int, error) {
+	return resolveWorkspaceId(client, flags, PartialMatch)
+}
+
+func resolveWorkspaceId(client *zube.Client, flags *pflag.FlagSet, match NameMatch) (int, error) {
+	if name, _ := flags.GetString("workspace"); name != "" {
+		workspaces := client.FetchWorkspaces(&zube.Query{})
+		workspace, err := FindByNameOrIdMatching(match, "workspace", name, workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		return workspace.Id, err
+	}
+
+	if workspaceId, _ := flags.GetInt("workspace-id"); workspaceId != 0 {
+		return workspaceId, nil
+	}
+
+	if name := defaultScope(flags, "default_workspace"); name != "" {
+		workspaces := client.FetchWorkspaces(&zube.Query{})
+		workspace, err := FindByNameOrIdMatching(match, "default workspace", name, workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		return workspace.Id, err
+	}
+
+	return 0, nil
+}
+
+// Returns the default project or workspace setting, unless a project or workspace was given with a flag.
+// Defaults only apply to commands that have these flags.
+func defaultScope(flags *pflag.FlagSet, key string) string {
+	scoped := false
+	for _, name := range []string{"project", "project-id", "workspace", "workspace-id"} {
+		if flags.Changed(name) {
+			return ""
+		}
+		scoped = scoped || flags.Lookup(name) != nil
+	}
+
+	if !scoped {
+		return ""
+	}
+	return viper.GetString(key)
+}
+
+// Finds the item named by a default setting such as default_project. Returns false if the setting is unset,
+// and an error if it names no item.
+func FindDefault[T any](key string, items []T, id func(T) int, name func(T) string) (T, bool, error) {
+	var zero T
+	value := viper.GetString(key)
+	if value == "" {
+		return zero, false, nil
+	}
+
+	item, err := FindExactByNameOrId(key, value, items, id, name)
+	if err != nil {
+		return zero, false, err
+	}
+	return item, true, nil
+}
+
+// Finds the project member named by the default_assignee setting, which may also be `@me`
+func FindDefaultAssignee(client *zube.Client, members []models.Member) (models.Member, bool, error) {
+	value := viper.GetString("default_assignee")
+	if value == "" {
+		return models.Member{}, false, nil
+	}
+
+	if value == "@me" {
+		me := client.FetchCurrentPerson()
+		member, ok := lo.Find(members, func(m models.Member) bool { return m.Person.Id == me.Id })
+		if !ok {
+			return member, false, fmt.Errorf("default_assignee @me is not a member of the project")
+		}
+		return member, true, nil
+	}
+
+	return FindDefault("default_assignee", members,
+		func(m models.Member) int { return zube.MemberIds(&[]models.Member{m})[0] },
+		func(m models.Member) string { return zube.MemberNames(&[]models.Member{m})[0] })
+}
+
+// Resolves `@me` to the current user, and any other name to a member of the project
+func resolveAssigneeId(client *zube.Client, name string, projectId int, match NameMatch) (int, error) {
+	if name == "@me" {
+		return client.FetchCurrentPerson().Id, nil
+	}
+
+	if projectId == 0 {
+		return 0, fmt.Errorf("--assignee requires --project or --project-id, unless it is @me")
+	}
+
+	members := client.FetchProjectMembers(projectId)
+	member, err := FindByNameMatching(match, "assignee", name, members, func(m models.Member) string {
+		return zube.MemberNames(&[]models.Member{m})[0]
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return zube.MemberIds(&[]models.Member{member})[0], nil
+}
+
+// Finds a sprint by its title or ID, or the open sprint with `@current-sprint`
+func FindSprint(sprints []models.Sprint, value string) (models.Sprint, error) {
+	return findSprint(sprints, value, PartialMatch)
+}
+
+func findSprint(sprints []models.Sprint, value string, match NameMatch) (models.Sprint, error) {
+	if value == "@current-sprint" {
+		return currentSprint(sprints)
+	}
+
+	return FindByNameOrIdMatching(match, "sprint", value, sprints,
+		func(s models.Sprint) int { return s.Id },
+		func(s models.Sprint) string { return s.Title })
+}
+
+// Returns the open sprint of a workspace
+func currentSprint(sprints []models.Sprint) (models.Sprint, error) {
+	for _, sprint := range sprints {
+		if sprint.State == "open" {
+			return sprint, nil
+		}
+	}
+
+	return models.Sprint{}, fmt.Errorf("there is no open sprint in the workspace")
+}
@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+	"github.com/samber/lo"
+)
+
+// Card fields that listings can be sorted by, mapped to the Zube API attributes they are ordered by
+var CardSortFields = map[string]string{
+	"number":   "number",
+	"priority": "priority",
+	"status":   "status",
+	"updated":  "updated_at",
+	"title":    "title",
+}
+
+// Card fields that listings can be grouped by
+var CardGroupFields = []string{"status", "category", "assignee", "epic", "label"}
+
+// Zube card statuses in workflow order
+var cardStatuses = []string{"backlog", "triage", "queued", "in_progress", "done", "archived"}
+
+// A group of cards sharing the same value of the grouped field
+type CardGroup struct {
+	Name  string        `json:"name"`
+	Count int           `json:"count"`
+	Cards []models.Card `json:"cards"`
+}
+
+// Returns an error listing the valid sort fields, if `field` is not one of them
+func ValidateCardSortField(field string) error {
+	if _, ok := CardSortFields[field]; !ok {
+		return fmt.Errorf("cannot sort by %q, valid fields are: %s", field, strings.Join(cardSortFieldNames(), ", "))
+	}
+	return nil
+}
+
+// Returns an error listing the valid group fields, if `field` is not one of them
+func ValidateCardGroupField(field string) error {
+	if !lo.Contains(CardGroupFields, field) {
+		return fmt.Errorf("cannot group by %q, valid fields are: %s", field, strings.Join(CardGroupFields, ", "))
+	}
+	return nil
+}
+
+// Sorts cards by a field in place. The sort is stable, and cards without a value come last in either direction.
+func SortCards(cards []models.Card, field string, desc bool) error {
+	if err := ValidateCardSortField(field); err != nil {
+		return err
+	}
+
+	type sortable struct {
+		card  models.Card
+		value any
+	}
+
+	items := make([]sortable, len(cards))
+	for i := range cards {
+		items[i] = sortable{cards[i], newCardRecord(&cards[i]).fields[CardSortFields[field]]}
+	}
+
+	// Statuses are sorted by workflow, like the groups of `--group-by status`
+	compare := compareValues
+	if field == "status" {
+		compare = compareStatuses
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].value, items[j].value
+
+		if isEmptyValue(a) || isEmptyValue(b) {
+			return !isEmptyValue(a) && isEmptyValue(b)
+		}
+
+		if desc {
+			return compare(b, a) < 0
+		}
+		return compare(a, b) < 0
+	})
+
+	for i := range items {
+		cards[i] = items[i].card
+	}
+
+	return nil
+}
+
+func isEmptyValue(value any) bool {
+	return value == nil || value == ""
+}
+
+// Compares two statuses by their workflow order, unknown statuses last and alphabetically
+func compareStatuses(a, b any) int {
+	i, j := uint(lo.IndexOf(cardStatuses, fmt.Sprint(a))), uint(lo.IndexOf(cardStatuses, fmt.Sprint(b)))
+	switch {
+	case i < j:
+		return -1
+	case i > j:
+		return 1
+	}
+	return compareValues(a, b)
+}
+
+// Compares two JSON values, numbers numerically and anything else as case-insensitive text
+func compareValues(a, b any) int {
+	x, xNumber := a.(int64)
+	y, yNumber := b.(int64)
+
+	if xNumber && yNumber {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(strings.ToLower(fmt.Sprint(a)), strings.ToLower(fmt.Sprint(b)))
+}
+
+// Groups cards by a field, keeping the order of the cards within each group. Cards with several assignees
+// or labels appear in each of their groups, cards without any value in a trailing "None" group.
+// The client is only used to look up epic titles, when grouping by epic.
+func GroupCards(client *zube.Client, cards []models.Card, field string) ([]CardGroup, error) {
+	if err := ValidateCardGroupField(field); err != nil {
+		return nil, err
+	}
+
+	var epicTitles map[int]string
+	if field == "epic" {
+		epicTitles = fetchEpicTitles(client, cards)
+	}
+
+	groups := make(map[string]*CardGroup)
+	var names []string
+
+	for _, card := range cards {
+		keys := cardGroupKeys(&card, field, epicTitles)
+		if len(keys) == 0 {
+			keys = []string{""}
+		}
+
+		for _, key := range keys {
+			group, ok := groups[key]
+			if !ok {
+				group = &CardGroup{Name: key}
+				groups[key] = group
+				names = append(names, key)
+			}
+			group.Cards = append(group.Cards, card)
+			group.Count++
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return compareGroupNames(field, names[i], names[j])
+	})
+
+	result := make([]CardGroup, 0, len(names))
+	for _, name := range names {
+		group := groups[name]
+		if group.Name == "" {
+			group.Name = "None"
+		}
+		result = append(result, *group)
+	}
+
+	return result, nil
+}
+
+func cardGroupKeys(card *models.Card, field string, epicTitles map[int]string) []string {
+	switch field {
+	case "status":
+		return []string{card.Status}
+	case "epic":
+		if card.EpicId == 0 {
+			return nil
+		}
+		if title, ok := epicTitles[card.EpicId]; ok {
+			return []string{title}
+		}
+		return []string{fmt.Sprintf("Epic %d", card.EpicId)}
+	}
+
+	return newCardRecord(card).Values(field)
+}
+
+// Statuses are ordered by workflow, everything else alphabetically. The "None" group always comes last.
+func compareGroupNames(field, a, b string) bool {
+	if a == "" || b == "" {
+		return a != "" && b == ""
+	}
+
+	if field == "status" {
+		i, j := lo.IndexOf(cardStatuses, a), lo.IndexOf(cardStatuses, b)
+		if i != j {
+			return uint(i) < uint(j)
+		}
+	}
+
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// Fetches the titles of the epics of every project the cards belong to
+func fetchEpicTitles(client *zube.Client, cards []models.Card) map[int]string {
+	titles := make(map[int]string)
+
+	if client == nil {
+		return titles
+	}
+
+	fetched := make(map[int]bool)
+	for _, card := range cards {
+		if card.EpicId == 0 || fetched[card.ProjectId] {
+			continue
+		}
+		fetched[card.ProjectId] = true
+
+		for _, epic := range client.FetchEpics(card.ProjectId) {
+			titles[epic.Id] = epic.Title
+		}
+	}
+
+	return titles
+}
+
+func cardSortFieldNames() []string {
+	names := make([]string, 0, len(CardSortFields))
+	for name := range CardSortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/markphelps/optional"
+	"github.com/platogo/zube/models"
+)
+
+func cardNumbers(cards []models.Card) []int {
+	numbers := make([]int, len(cards))
+	for i, card := range cards {
+		numbers[i] = card.Number
+	}
+	return numbers
+}
+
+func TestSortCards(t *testing.T) {
+	cards := []models.Card{
+		{Number: 3, Title: "banana", Status: "done", Priority: optional.NewInt(2)},
+		{Number: 1, Title: "Cherry", Status: "queued"},
+		{Number: 2, Title: "apple", Status: "backlog", Priority: optional.NewInt(1)},
+	}
+
+	tests := []struct {
+		field string
+		desc  bool
+		want  []int
+	}{
+		{"number", false, []int{1, 2, 3}},
+		{"number", true, []int{3, 2, 1}},
+		{"title", false, []int{2, 3, 1}},
+		{"priority", false, []int{2, 3, 1}},
+		{"priority", true, []int{3, 2, 1}},
+		{"status", false, []int{2, 1, 3}},
+		{"status", true, []int{3, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]models.Card{}, cards...)
+
+		if err := SortCards(sorted, tt.field, tt.desc); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := cardNumbers(sorted); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sort by %s (desc: %v): got %v, want %v", tt.field, tt.desc, got, tt.want)
+		}
+	}
+
+	if err := SortCards(cards, "color", false); err == nil {
+		t.Error("expected an error for an unknown sort field")
+	}
+}
+
+func TestGroupCards(t *testing.T) {
+	cards := []models.Card{
+		{Number: 1, Status: "done"},
+		{Number: 2, Status: "in_progress"},
+		{Number: 3, Status: "backlog"},
+		{Number: 4, Status: "in_progress"},
+		{Number: 5},
+	}
+
+	groups, err := GroupCards(nil, cards, "status")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+
+	if want := []string{"backlog", "in_progress", "done", "None"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got groups %v, want %v", names, want)
+	}
+
+	if groups[1].Count != 2 || !reflect.DeepEqual(cardNumbers(groups[1].Cards), []int{2, 4}) {
+		t.Errorf("got %+v, want cards 2 and 4", groups[1])
+	}
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/platogo/zube-cli/internal/ansi"
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// Columns narrower than this are never shrunk to fit the terminal
+const minFlexColumnWidth = 12
+
+// A column of a table
+type TableColumn struct {
+	Key    string
+	Header string
+	Flex   bool // shrunk and truncated when the table is wider than the terminal
+}
+
+// A table row, with the cells keyed by column
+type TableRow map[string]string
+
+// Renders rows as a table sized to the terminal, with the columns selected by `--columns` (or the default
+// columns), all columns with `--wide`, and without the header with `--no-headers`
+type Table struct {
+	w        io.Writer
+	columns  []TableColumn
+	widths   []int
+	maxWidth int
+	wide     bool
+	started  bool
+}
+
+// Creates a table of the columns selected with `--columns` out of the available ones, or else of the
+// default ones. `--wide` selects all available columns. Fails for unknown columns.
+func NewTable(available []TableColumn, defaults ...string) (*Table, error) {
+	keys := viper.GetStringSlice("columns")
+	wide := viper.GetBool("wide")
+
+	switch {
+	case len(keys) > 0:
+	case wide || len(defaults) == 0:
+		keys = lo.Map(available, func(c TableColumn, _ int) string { return c.Key })
+	default:
+		keys = defaults
+	}
+
+	var columns []TableColumn
+	for _, key := range keys {
+		column, ok := lo.Find(available, func(c TableColumn) bool { return c.Key == strings.ToLower(strings.TrimSpace(key)) })
+		if !ok {
+			names := lo.Map(available, func(c TableColumn, _ int) string { return c.Key })
+			return nil, fmt.Errorf("unknown column %q, available columns are: %s", key, strings.Join(names, ", "))
+		}
+		columns = append(columns, column)
+	}
+
+	return &Table{w: os.Stdout, columns: columns, maxWidth: terminalWidth(), wide: wide}, nil
+}
+
+// Like `NewTable`, but exits on unknown columns
+func MustNewTable(available []TableColumn, defaults ...string) *Table {
+	table, err := NewTable(available, defaults...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return table
+}
+
+// Prints rows. The column widths are laid out by the rows of the first call, so later calls, e.g. for
+// further pages, continue the same table.
+func (t *Table) Render(rows []TableRow) {
+	if !t.started {
+		t.started = true
+		t.widths = t.layout(rows)
+
+		if !viper.GetBool("no-headers") {
+			headers := make([]string, len(t.columns))
+			rulers := make([]string, len(t.columns))
+			for i, column := range t.columns {
+				headers[i] = column.Header
+				rulers[i] = strings.Repeat("-", t.widths[i])
+			}
+			t.printLine(headers)
+			t.printLine(rulers)
+		}
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(t.columns))
+		for i, column := range t.columns {
+			cells[i] = row[column.Key]
+		}
+		t.printLine(cells)
+	}
+}
+
+func (t *Table) printLine(cells []string) {
+	var line strings.Builder
+
+	for i, cell := range cells {
+		if t.truncates() {
+			cell = fitCell(cell, t.widths[i])
+		}
+
+		if i < len(cells)-1 {
+			cell += strings.Repeat(" ", lo.Max([]int{t.widths[i] - ansi.Width(cell), 0})+1)
+		}
+		line.WriteString(cell)
+	}
+
+	fmt.Fprintln(t.w, line.String())
+}
+
+// Sizes every column to its widest cell. If that doesn't fit the terminal, the flexible columns share
+// the remaining space: narrow ones keep their width, the wider ones get an equal share of the rest.
+func (t *Table) layout(rows []TableRow) []int {
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		if !viper.GetBool("no-headers") {
+			widths[i] = ansi.Width(column.Header)
+		}
+		for _, row := range rows {
+			widths[i] = lo.Max([]int{widths[i], ansi.Width(row[column.Key])})
+		}
+	}
+
+	if !t.truncates() {
+		return widths
+	}
+
+	available := t.maxWidth - (len(widths) - 1)
+	var flexible []int
+	for i, column := range t.columns {
+		if column.Flex {
+			flexible = append(flexible, i)
+		} else {
+			available -= widths[i]
+		}
+	}
+
+	for len(flexible) > 0 {
+		share := lo.Max([]int{available / len(flexible), minFlexColumnWidth})
+
+		narrow := lo.Filter(flexible, func(i int, _ int) bool { return widths[i] <= share })
+		if len(narrow) == 0 {
+			for _, i := range flexible {
+				widths[i] = share
+			}
+			break
+		}
+
+		for _, i := range narrow {
+			available -= widths[i]
+		}
+		flexible = lo.Without(flexible, narrow...)
+	}
+
+	return widths
+}
+
+// Tables are only truncated to fit a terminal, never with `--wide` or when piped
+func (t *Table) truncates() bool {
+	return !t.wide && t.maxWidth > 0
+}
+
+// Truncates a cell to the given visible width, with an ellipsis. Truncated cells lose their colors.
+func fitCell(cell string, width int) string {
+	if ansi.Width(cell) <= width {
+		return cell
+	}
+
+	plain := ansi.Strip(cell)
+	if width <= 3 {
+		return TruncateString(plain, width)
+	}
+	return TruncateString(plain, width-3) + "..."
+}
+
+// The width of the terminal, or 0 if stdout is not a terminal
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
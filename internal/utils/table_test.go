@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/platogo/zube/models"
+	"github.com/spf13/viper"
+)
+
+var testColumns = []TableColumn{
+	{Key: "id", Header: "ID"},
+	{Key: "title", Header: "Title", Flex: true},
+	{Key: "status", Header: "Status"},
+}
+
+func renderTable(t *testing.T, maxWidth int, rows []TableRow, defaults ...string) string {
+	t.Helper()
+
+	table, err := NewTable(testColumns, defaults...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	table.w = &out
+	table.maxWidth = maxWidth
+	table.Render(rows)
+
+	return out.String()
+}
+
+func TestTableRender(t *testing.T) {
+	rows := []TableRow{
+		{"id": "1", "title": "A rather long card title that needs truncation", "status": "Queued"},
+		{"id": "22", "title": "Short", "status": "In Progress"},
+	}
+
+	tests := []struct {
+		name     string
+		maxWidth int
+		want     string
+	}{
+		{"fits", 0, `
+ID Title                                          Status
+-- ---------------------------------------------- -----------
+1  A rather long card title that needs truncation Queued
+22 Short                                          In Progress
+`},
+		{"truncates flexible columns", 35, `
+ID Title                Status
+-- -------------------- -----------
+1  A rather long car... Queued
+22 Short                In Progress
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTable(t, tt.maxWidth, rows); got != strings.TrimPrefix(tt.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableColumns(t *testing.T) {
+	defer viper.Reset()
+	rows := []TableRow{{"id": "1", "title": "Title", "status": "Done"}}
+
+	if got := renderTable(t, 0, rows, "id", "title"); got != "ID Title\n-- -----\n1  Title\n" {
+		t.Errorf("default columns: got %q", got)
+	}
+
+	viper.Set("columns", []string{"status", "id"})
+	viper.Set("no-headers", true)
+
+	if got := renderTable(t, 0, rows, "id", "title"); got != "Done 1\n" {
+		t.Errorf("selected columns without headers: got %q", got)
+	}
+
+	viper.Set("columns", []string{"assignees"})
+	if _, err := NewTable(testColumns); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestCardEpicColumn(t *testing.T) {
+	defer viper.Reset()
+	defer func() { epicTitles = make(map[int]string) }()
+
+	if cardTableShows("epic") || !cardTableShows("title") {
+		t.Error("expected only the default columns to be shown")
+	}
+	viper.Set("columns", []string{"number", " Epic"})
+	if !cardTableShows("epic") || cardTableShows("title") {
+		t.Error("expected only the selected columns to be shown")
+	}
+
+	epicTitles[7] = "Checkout"
+	rows := cardRows([]models.Card{{Number: 1, EpicId: 7}, {Number: 2, EpicId: 8}})
+	if rows[0]["epic"] != "Checkout" || rows[1]["epic"] != "8" {
+		t.Errorf("expected the epic title, or the ID if unknown, got %q and %q", rows[0]["epic"], rows[1]["epic"])
+	}
+}
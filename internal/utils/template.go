@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/gookit/color"
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/platogo/zube-cli/internal/jq"
+)
+
+// Helpers available in `--template` templates, in addition to the text/template builtins
+var templateFuncs = template.FuncMap{
+	"snakeToTitle": SnakeCaseToTitleCase,
+	"truncate": func(maxLen int, s string) string {
+		if utf8.RuneCountInString(s) > maxLen {
+			return TruncateString(s, maxLen) + "..."
+		}
+		return s
+	},
+	"color": colorize,
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+var namedColors = map[string]func(any) aurora.Value{
+	"black":   aurora.Black,
+	"red":     aurora.Red,
+	"green":   aurora.Green,
+	"yellow":  aurora.Yellow,
+	"blue":    aurora.Blue,
+	"magenta": aurora.Magenta,
+	"cyan":    aurora.Cyan,
+	"white":   aurora.White,
+	"bold":    aurora.Bold,
+}
+
+// Colors a value either by color name, e.g. `green`, or by hex code, e.g. `#ff0000`
+func colorize(name string, v any) (string, error) {
+	if strings.HasPrefix(name, "#") {
+		return color.HEX(name, false).Sprint(v), nil
+	}
+
+	if colorFn, ok := namedColors[name]; ok {
+		return fmt.Sprint(colorFn(v)), nil
+	}
+
+	return "", fmt.Errorf("unknown color %q", name)
+}
+
+// Parses a `--template` Go template
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("output").Funcs(templateFuncs).Parse(text)
+}
+
+// Renders a Go template once for every item of a slice, or once for a single item.
+// Every rendered item ends with a newline.
+func WriteTemplate(w io.Writer, text string, v any) error {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	items := []any{v}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		items = make([]any, value.Len())
+		for i := range items {
+			items[i] = value.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		var buf bytes.Buffer
+
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return err
+		}
+
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Filters the JSON representation of any value with a jq program. String results are printed raw,
+// everything else as compact JSON, one result per line.
+func WriteJQ(w io.Writer, program string, v any) error {
+	query, err := jq.Parse(program)
+	if err != nil {
+		return fmt.Errorf("invalid jq filter: %w", err)
+	}
+
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	results, err := query.Run(generic)
+	if err != nil {
+		return fmt.Errorf("jq filter failed: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
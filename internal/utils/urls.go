@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/platogo/zube/models"
+)
+
+// The Zube web app. Card URLs are built by `zube.CardUrl`, the URLs of everything else here.
+const zubeWebUrl = "https://zube.io"
+
+func ProjectUrl(account *models.Account, project *models.Project) string {
+	return fmt.Sprintf("%s/%s/%s", zubeWebUrl, account.Slug, project.Slug)
+}
+
+func WorkspaceUrl(account *models.Account, project *models.Project, workspace *models.Workspace) string {
+	return fmt.Sprintf("%s/w/%s/kanban", ProjectUrl(account, project), workspace.Slug)
+}
+
+func EpicUrl(account *models.Account, project *models.Project, epic *models.Epic) string {
+	return fmt.Sprintf("%s/e/%d", ProjectUrl(account, project), epic.Number)
+}
+
+func SprintUrl(account *models.Account, project *models.Project, workspace *models.Workspace, sprint *models.Sprint) string {
+	return fmt.Sprintf("%s/w/%s/sprints/%d", ProjectUrl(account, project), workspace.Slug, sprint.Id)
+}
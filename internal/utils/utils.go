@@ -3,6 +3,7 @@ package utils
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -36,6 +37,11 @@ func SnakeCaseToTitleCase(s string) string {
 	return cases.Title(language.English).String(spacedWords)
 }
 
+// Converts a title case string (e.g. `In Progress`) to snake case (e.g. `in_progress`)
+func TitleCaseToSnakeCase(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "_")
+}
+
 // Constructs a zube `Query` from Cobra flags
 func NewQueryFromFlags(flags *pflag.FlagSet) zube.Query {
 	var query zube.Query
@@ -84,12 +90,19 @@ func NewQueryFromFlags(flags *pflag.FlagSet) zube.Query {
 		where["state"] = state
 	}
 
-	status, ok := flags.GetString("status")
-	if ok == nil && status != "" {
-		where["status"] = status
+	// Several statuses are filtered client-side, see `CardFilterFromFlags`. Both accept "In Progress"
+	// as well as "in_progress".
+	statuses, ok := flags.GetStringSlice("status")
+	if ok == nil && len(statuses) == 1 && statuses[0] != "" {
+		where["status"] = TitleCaseToSnakeCase(statuses[0])
+	}
+	query.Filter = zube.Filter{Where: where}
+
+	// Only the table output of the default columns can get away with partial cards
+	if !IsStructuredOutput() && cardTableIsPartial() {
+		selectedCols := [4]string{"number", "title", "status", "category_name"}
+		query.Filter.Select = selectedCols[:]
 	}
-	selectedCols := [4]string{"number", "title", "status", "category_name"}
-	query.Filter = zube.Filter{Where: where, Select: selectedCols[:]}
 
 	return query
 }
@@ -107,3 +120,14 @@ func StringPrompt(label string) string {
 	}
 	return strings.TrimSpace(s)
 }
+
+// Reads the whole content of a file, or of stdin if the path is `-`
+func ReadFileOrStdin(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
@@ -38,3 +38,14 @@ func TestSnakeCaseToTitleCase(t *testing.T) {
 		t.Errorf("expected %s got %s", want, res)
 	}
 }
+
+func TestTitleCaseToSnakeCase(t *testing.T) {
+	example := "In Progress"
+	want := "in_progress"
+
+	res := TitleCaseToSnakeCase(example)
+
+	if res != want {
+		t.Errorf("expected %s got %s", want, res)
+	}
+}
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube-cli/internal/zql"
+	"github.com/platogo/zube/models"
+)
+
+// ZQL fields for cards, mapped to the Zube API card attributes they are read from
+var cardZQLFields = map[string]string{
+	"number":    "number",
+	"title":     "title",
+	"body":      "body",
+	"status":    "status",
+	"state":     "state",
+	"category":  "category_name",
+	"priority":  "priority",
+	"project":   "project_id",
+	"workspace": "workspace_id",
+	"epic":      "epic_id",
+	"sprint":    "sprint_id",
+	"label":     "labels",
+	"assignee":  "assignees",
+	"created":   "created_at",
+	"updated":   "updated_at",
+	"closed":    "closed_at",
+}
+
+// ZQL fields that can be sent to the Zube API as `where` filters
+var apiZQLFields = []string{"number", "status", "state", "category", "priority", "project", "workspace", "epic", "sprint"}
+
+// ZQL fields whose values are IDs, and can be given as numbers only
+var idZQLFields = map[string]bool{"number": true, "priority": true, "epic": true, "sprint": true}
+
+// Applies a ZQL query to a zube `Query`. Every top-level term the Zube API can filter by is added to the
+// query's `where` filters, unless already filtered by a flag, and with `search` set, bare words become the
+// search text. Project and workspace names are matched as given by `match`. Returns a predicate for the
+// remaining terms, which must be applied client-side, or nil.
+func ApplyZQL(client *zube.Client, source string, query *zube.Query, search bool, match NameMatch) (CardPredicate, error) {
+	parsed, err := zql.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveZQLValues(client, parsed, match); err != nil {
+		return nil, err
+	}
+
+	if query.Filter.Where == nil {
+		query.Filter.Where = make(map[string]any)
+	}
+	where := query.Filter.Where
+
+	var searchText []string
+
+	_, rest := parsed.Split(func(node zql.Node) bool {
+		switch node := node.(type) {
+		case *zql.Text:
+			if search {
+				searchText = append(searchText, node.Value)
+				return true
+			}
+		case *zql.Comparison:
+			key := cardZQLFields[node.Field]
+			if node.Op != ":" || len(node.Values) != 1 || !isAPIField(node.Field) || where[key] != nil {
+				return false
+			}
+
+			value := node.Values[0]
+			if strings.EqualFold(value, "none") {
+				return false
+			}
+
+			if number, err := strconv.Atoi(value); err == nil && node.Field != "category" && node.Field != "status" && node.Field != "state" {
+				where[key] = number
+			} else {
+				where[key] = value
+			}
+			return true
+		}
+		return false
+	})
+
+	if len(searchText) > 0 {
+		query.Search = strings.TrimSpace(query.Search + " " + strings.Join(searchText, " "))
+	}
+
+	if len(rest.(*zql.And).Terms) == 0 {
+		return nil, nil
+	}
+
+	// Client-side filters need the full cards
+	query.Filter.Select = nil
+
+	return func(card *models.Card) bool {
+		return zql.Match(rest, newCardRecord(card))
+	}, nil
+}
+
+func isAPIField(field string) bool {
+	for _, f := range apiZQLFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Validates the fields of a query, and resolves `@me`, statuses as well as project and workspace names into
+// the values that are stored on cards
+func resolveZQLValues(client *zube.Client, query *zql.Query, match NameMatch) error {
+	var err error
+	var username *string
+
+	zql.Walk(query.Root, func(node zql.Node) {
+		comparison, ok := node.(*zql.Comparison)
+		if !ok || err != nil {
+			return
+		}
+
+		if _, known := cardZQLFields[comparison.Field]; !known {
+			err = &zql.Error{Query: query.Source, Pos: comparison.Pos,
+				Reason: fmt.Sprintf("unknown field %q, expected one of %s", comparison.Field, strings.Join(zqlFieldNames(), ", "))}
+			return
+		}
+
+		for i, value := range comparison.Values {
+			if strings.EqualFold(value, "none") {
+				continue
+			}
+
+			var resolved string
+			resolved, err = resolveZQLValue(client, comparison.Field, value, &username, match)
+			if err != nil {
+				err = &zql.Error{Query: query.Source, Pos: comparison.Pos, Reason: err.Error()}
+				return
+			}
+			comparison.Values[i] = resolved
+		}
+	})
+
+	return err
+}
+
+// The current user's username is cached in `username`, so it is only fetched once per query
+func resolveZQLValue(client *zube.Client, field, value string, username **string, match NameMatch) (string, error) {
+	switch {
+	case field == "assignee" && value == "@me":
+		if *username == nil {
+			current := client.FetchCurrentPerson().Username
+			*username = &current
+		}
+		return **username, nil
+	case field == "status":
+		// Like --status, e.g. `In Progress` is the `in_progress` status
+		return TitleCaseToSnakeCase(value), nil
+	case field == "project":
+		projects := client.FetchProjects(&zube.Query{})
+		project, err := FindByNameOrIdMatching(match, "project", value, projects,
+			func(p models.Project) int { return p.Id },
+			func(p models.Project) string { return p.Name })
+		return strconv.Itoa(project.Id), err
+	case field == "workspace":
+		workspaces := client.FetchWorkspaces(&zube.Query{})
+		workspace, err := FindByNameOrIdMatching(match, "workspace", value, workspaces,
+			func(w models.Workspace) int { return w.Id },
+			func(w models.Workspace) string { return w.Name })
+		return strconv.Itoa(workspace.Id), err
+	case idZQLFields[field]:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("%s must be a number, got %q", field, value)
+		}
+	}
+
+	return value, nil
+}
+
+func zqlFieldNames() []string {
+	names := make([]string, 0, len(cardZQLFields))
+	for name := range cardZQLFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Exposes a card to ZQL matching, based on its Zube API representation
+type cardRecord struct {
+	fields map[string]any
+}
+
+func newCardRecord(card *models.Card) cardRecord {
+	generic, _ := toGeneric(card)
+	fields, _ := generic.(map[string]any)
+	return cardRecord{fields: fields}
+}
+
+func (r cardRecord) Values(field string) []string {
+	value := r.fields[cardZQLFields[field]]
+
+	switch field {
+	case "label":
+		return collectStrings(value, "name")
+	case "assignee":
+		return collectStrings(value, "username", "name")
+	}
+
+	if value == nil || value == "" {
+		return nil
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+func (r cardRecord) Text() string {
+	return fmt.Sprint(r.fields["title"], " ", r.fields["body"])
+}
+
+// Collects the given keys of every object in a JSON array
+func collectStrings(value any, keys ...string) []string {
+	items, _ := value.([]any)
+
+	var values []string
+	for _, item := range items {
+		object, _ := item.(map[string]any)
+		for _, key := range keys {
+			if s, ok := object[key].(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
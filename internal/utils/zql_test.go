@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/platogo/zube"
+	"github.com/platogo/zube/models"
+)
+
+func TestApplyZQLStatus(t *testing.T) {
+	var query zube.Query
+	if _, err := ApplyZQL(&zube.Client{}, `status:"In Progress" category:Inbox`, &query, false, PartialMatch); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{"status": "in_progress", "category_name": "Inbox"}
+	if !reflect.DeepEqual(query.Filter.Where, want) {
+		t.Errorf("expected %v, got %v", want, query.Filter.Where)
+	}
+
+	// Statuses filtered client-side are normalized the same way
+	query = zube.Query{}
+	predicate, err := ApplyZQL(&zube.Client{}, `status:"In Progress" OR status:Done`, &query, false, PartialMatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if predicate == nil || !predicate(&models.Card{Status: "in_progress"}) || predicate(&models.Card{Status: "open"}) {
+		t.Error("expected the predicate to match in_progress cards only")
+	}
+}
@@ -0,0 +1,32 @@
+package zql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// A syntax or validation error at a given position of a ZQL query
+type Error struct {
+	Query  string
+	Pos    int // byte offset in the query
+	Reason string
+}
+
+func newError(query string, pos int, format string, args ...any) *Error {
+	return &Error{Query: query, Pos: pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Column of the error, starting at 1
+func (e *Error) Column() int {
+	return utf8.RuneCountInString(e.Query[:e.Pos]) + 1
+}
+
+// Formats the error with the query and a caret pointing at the error position, e.g.
+//
+//	invalid query: expected value after "status:" at column 8
+//	  status:
+//	         ^
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query: %s at column %d\n  %s\n  %s^", e.Reason, e.Column(), e.Query, strings.Repeat(" ", e.Column()-1))
+}
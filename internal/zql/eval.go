@@ -0,0 +1,102 @@
+package zql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Anything a query can be matched against, e.g. a card
+type Record interface {
+	// All values of a field, e.g. every label name for `label`
+	Values(field string) []string
+	// The text that bare words are matched against
+	Text() string
+}
+
+// Checks whether a record matches the query
+func (q *Query) Match(record Record) bool {
+	return Match(q.Root, record)
+}
+
+// Checks whether a record matches a query node
+func Match(node Node, record Record) bool {
+	switch node := node.(type) {
+	case *And:
+		for _, term := range node.Terms {
+			if !Match(term, record) {
+				return false
+			}
+		}
+		return true
+	case *Or:
+		for _, term := range node.Terms {
+			if Match(term, record) {
+				return true
+			}
+		}
+		return false
+	case *Not:
+		return !Match(node.Term, record)
+	case *Text:
+		return strings.Contains(strings.ToLower(record.Text()), strings.ToLower(node.Value))
+	case *Comparison:
+		return matchComparison(node, record.Values(node.Field))
+	}
+	return false
+}
+
+func matchComparison(c *Comparison, actual []string) bool {
+	switch c.Op {
+	case ":":
+		return matchesAny(c.Values, actual)
+	case "!=":
+		return !matchesAny(c.Values, actual)
+	}
+
+	for _, value := range actual {
+		cmp := compareValues(value, c.Values[0])
+
+		switch {
+		case c.Op == "<" && cmp < 0,
+			c.Op == "<=" && cmp <= 0,
+			c.Op == ">" && cmp > 0,
+			c.Op == ">=" && cmp >= 0:
+			return true
+		}
+	}
+	return false
+}
+
+// `none` matches records without any value for the field
+func matchesAny(expected, actual []string) bool {
+	for _, want := range expected {
+		if strings.EqualFold(want, "none") && len(actual) == 0 {
+			return true
+		}
+
+		for _, value := range actual {
+			if strings.EqualFold(value, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Compares numerically if both values are numbers, and case-insensitively otherwise, which also orders ISO dates
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,163 @@
+package zql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOperator
+	tokComma
+	tokMinus
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of query"
+	case tokWord, tokString:
+		return "value"
+	case tokOperator:
+		return "operator"
+	case tokComma:
+		return "\",\""
+	case tokMinus:
+		return "\"-\""
+	case tokLParen:
+		return "\"(\""
+	case tokRParen:
+		return "\")\""
+	case tokAnd:
+		return "AND"
+	case tokOr:
+		return "OR"
+	case tokNot:
+		return "NOT"
+	}
+	return "token"
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the query
+}
+
+// Operators, longest first
+var operators = []string{"<=", ">=", "!=", ":", "<", ">", "="}
+
+const specialChars = `():<>=!,"`
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+		case r == '"':
+			value, end, err := lexString(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokString, value, i})
+			i = end
+		case r == '-' && startsTerm(tokens) && i+1 < len(input) && !unicode.IsSpace(rune(input[i+1])):
+			tokens = append(tokens, token{tokMinus, "-", i})
+			i++
+		case strings.ContainsRune(specialChars, r):
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(input[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, newError(input, i, "unexpected %q", string(r))
+			}
+			tokens = append(tokens, token{tokOperator, op, i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(input) {
+				r, size := utf8.DecodeRuneInString(input[i:])
+				if unicode.IsSpace(r) || strings.ContainsRune(specialChars, r) {
+					break
+				}
+				i += size
+			}
+
+			word := input[start:i]
+			kind := tokWord
+			switch word {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			case "NOT":
+				kind = tokNot
+			}
+			tokens = append(tokens, token{kind, word, start})
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(input)}), nil
+}
+
+// A `-` only negates at the start of a term, not in values like `priority>-1`.
+// Dashes inside words, e.g. in `2026-01-01`, never reach this check.
+func startsTerm(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+
+	switch tokens[len(tokens)-1].kind {
+	case tokComma, tokOperator:
+		return false
+	}
+	return true
+}
+
+// Lexes a double quoted string with backslash escapes, returning its value and the offset after it
+func lexString(input string, start int) (string, int, error) {
+	var value strings.Builder
+
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			if i+1 < len(input) {
+				i++
+				value.WriteByte(input[i])
+			}
+		case '"':
+			return value.String(), i + 1, nil
+		default:
+			value.WriteByte(input[i])
+		}
+	}
+
+	return "", 0, newError(input, start, "unterminated string")
+}
@@ -0,0 +1,147 @@
+package zql
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// or := and (OR and)*
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := []Node{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return &Or{Terms: terms}, nil
+}
+
+// and := unary ((AND)? unary)*
+func (p *parser) parseAnd() (Node, error) {
+	var terms []Node
+
+	for {
+		tok := p.peek()
+
+		switch tok.kind {
+		case tokEOF, tokRParen, tokOr:
+			if len(terms) == 0 && tok.kind != tokEOF {
+				return nil, newError(p.input, tok.pos, "expected a term before %s", tok.kind)
+			}
+			if len(terms) == 1 {
+				return terms[0], nil
+			}
+			return &And{Terms: terms}, nil
+		case tokAnd:
+			if len(terms) == 0 {
+				return nil, newError(p.input, tok.pos, "expected a term before AND")
+			}
+			p.next()
+			if next := p.peek(); next.kind == tokEOF || next.kind == tokRParen || next.kind == tokOr {
+				return nil, newError(p.input, next.pos, "expected a term after AND")
+			}
+		}
+
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+}
+
+// unary := ('-' | NOT) unary | primary
+func (p *parser) parseUnary() (Node, error) {
+	if kind := p.peek().kind; kind == tokMinus || kind == tokNot {
+		p.next()
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Term: term}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// primary := '(' or ')' | value (operator value (',' value)*)?
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, newError(p.input, tok.pos, "unclosed \"(\"")
+		}
+		return inner, nil
+	case tokWord, tokString:
+		if p.peek().kind != tokOperator {
+			return &Text{Value: tok.text, Pos: tok.pos}, nil
+		}
+		if tok.kind == tokString {
+			return nil, newError(p.input, tok.pos, "field names cannot be quoted")
+		}
+		return p.parseComparison(tok)
+	case tokOperator:
+		return nil, newError(p.input, tok.pos, "expected a field name before %q", tok.text)
+	}
+
+	return nil, newError(p.input, tok.pos, "unexpected %s", tok.kind)
+}
+
+func (p *parser) parseComparison(field token) (Node, error) {
+	op := p.next()
+
+	comparison := &Comparison{Field: field.text, Op: op.text, Pos: field.pos}
+	if comparison.Op == "=" {
+		comparison.Op = ":"
+	}
+
+	for {
+		value := p.next()
+		if value.kind != tokWord && value.kind != tokString {
+			return nil, newError(p.input, value.pos, "expected a value after %q", p.input[field.pos:value.pos])
+		}
+		comparison.Values = append(comparison.Values, value.text)
+
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+
+	if len(comparison.Values) > 1 && comparison.Op != ":" && comparison.Op != "!=" {
+		return nil, newError(p.input, op.pos, "%q only accepts a single value", comparison.Op)
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,154 @@
+// Package zql implements the Zube Query Language, a compact alternative to filter flags, e.g.
+//
+//	status:in_progress assignee:@me label:bug,feature priority<=2 -category:Done (epic:12 OR "export")
+//
+// Terms are `field:value` equality tests (a comma separated list matches any of the values), comparisons
+// with `<`, `<=`, `>`, `>=` and `!=`, or bare words that are matched against the card text.
+// Terms are combined with an implicit or explicit AND, with OR, and can be negated with `-` or NOT
+// and grouped with parentheses. Values containing spaces or special characters can be double quoted.
+package zql
+
+import (
+	"strings"
+)
+
+// A node of a parsed query
+type Node interface {
+	node()
+}
+
+// All terms must match
+type And struct {
+	Terms []Node
+}
+
+// Any of the terms must match
+type Or struct {
+	Terms []Node
+}
+
+// The term must not match
+type Not struct {
+	Term Node
+}
+
+// A `field<op>value` term. `Op` is one of `:`, `!=`, `<`, `<=`, `>` and `>=`.
+type Comparison struct {
+	Field  string
+	Op     string
+	Values []string
+	Pos    int
+}
+
+// A bare word or quoted phrase, matched against the card text
+type Text struct {
+	Value string
+	Pos   int
+}
+
+func (*And) node()        {}
+func (*Or) node()         {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+func (*Text) node()       {}
+
+// A parsed ZQL query
+type Query struct {
+	Source string
+	Root   Node
+}
+
+// Parses a ZQL query. An empty query matches everything.
+func Parse(input string) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{input: input, tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, newError(input, tok.pos, "unexpected %s", tok.kind)
+	}
+
+	return &Query{Source: input, Root: root}, nil
+}
+
+// Calls `fn` for every node of the tree, parents first
+func Walk(node Node, fn func(Node)) {
+	fn(node)
+
+	switch node := node.(type) {
+	case *And:
+		for _, term := range node.Terms {
+			Walk(term, fn)
+		}
+	case *Or:
+		for _, term := range node.Terms {
+			Walk(term, fn)
+		}
+	case *Not:
+		Walk(node.Term, fn)
+	}
+}
+
+// Splits the top-level AND terms of a query into the terms accepted by `take` and the remaining query.
+// This is used to send every term the Zube API can express as a filter, and apply the rest client-side.
+func (q *Query) Split(take func(Node) bool) ([]Node, Node) {
+	terms := []Node{q.Root}
+	if and, ok := q.Root.(*And); ok {
+		terms = and.Terms
+	}
+
+	var taken, rest []Node
+	for _, term := range terms {
+		if take(term) {
+			taken = append(taken, term)
+		} else {
+			rest = append(rest, term)
+		}
+	}
+
+	return taken, &And{Terms: rest}
+}
+
+// Formats a node back into ZQL
+func Format(node Node) string {
+	switch node := node.(type) {
+	case *And:
+		return joinNodes(node.Terms, " ")
+	case *Or:
+		return "(" + joinNodes(node.Terms, " OR ") + ")"
+	case *Not:
+		return "-" + Format(node.Term)
+	case *Comparison:
+		values := make([]string, len(node.Values))
+		for i, value := range node.Values {
+			values[i] = quote(value)
+		}
+		return node.Field + node.Op + strings.Join(values, ",")
+	case *Text:
+		return quote(node.Value)
+	}
+	return ""
+}
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = Format(node)
+	}
+	return strings.Join(parts, sep)
+}
+
+func quote(value string) string {
+	if value == "" || strings.ContainsAny(value, specialChars+" \t\n") {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	return value
+}
@@ -0,0 +1,181 @@
+package zql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"status:in_progress", "status:in_progress"},
+		{"status:in_progress assignee:@me", "status:in_progress assignee:@me"},
+		{"status:in_progress AND label:bug", "status:in_progress label:bug"},
+		{"-category:Done", "-category:Done"},
+		{"NOT category:Done", "-category:Done"},
+		{"priority<=2 priority>0", "priority<=2 priority>0"},
+		{"label:bug,feature", "label:bug,feature"},
+		{"status=open", "status:open"},
+		{`category:"In Progress"`, `category:"In Progress"`},
+		{"label:bug OR label:feature", "(label:bug OR label:feature)"},
+		{"status:open (label:bug OR -epic:none)", "status:open (label:bug OR -epic:none)"},
+		{"updated>=2026-01-01 login", "updated>=2026-01-01 login"},
+		{`"fix login" -wontfix`, `"fix login" -wontfix`},
+		{"a OR b c", "(a OR b c)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := Format(query.Root); got != tt.want {
+				t.Errorf("expected %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseOrPrecedence(t *testing.T) {
+	query, err := Parse("a OR b c")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	or, ok := query.Root.(*Or)
+	if !ok || len(or.Terms) != 2 {
+		t.Fatalf("expected an OR of two terms, got %s", Format(query.Root))
+	}
+
+	if and, ok := or.Terms[1].(*And); !ok || len(and.Terms) != 2 {
+		t.Errorf("expected AND to bind tighter than OR, got %s", Format(query.Root))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+		reason string
+	}{
+		{"status:", 8, "expected a value after \"status:\""},
+		{"(status:open", 1, "unclosed \"(\""},
+		{"status:open)", 12, "unexpected \")\""},
+		{":open", 1, "expected a field name"},
+		{"priority<1,2", 9, "only accepts a single value"},
+		{`title:"unterminated`, 7, "unterminated string"},
+		{"OR status:open", 1, "expected a term before OR"},
+		{"status:open AND", 16, "expected a term after AND"},
+		{"status!open", 7, "unexpected \"!\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+
+			zqlErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("expected a ZQL error, got %v", err)
+			}
+
+			if zqlErr.Column() != tt.column || !strings.Contains(zqlErr.Reason, tt.reason) {
+				t.Errorf("expected %q at column %d, got %q at column %d", tt.reason, tt.column, zqlErr.Reason, zqlErr.Column())
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	_, err := Parse("status:")
+
+	want := "invalid query: expected a value after \"status:\" at column 8\n  status:\n         ^"
+	if err.Error() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, err.Error())
+	}
+}
+
+type record struct {
+	fields map[string][]string
+	text   string
+}
+
+func (r record) Values(field string) []string { return r.fields[field] }
+func (r record) Text() string                 { return r.text }
+
+func TestMatch(t *testing.T) {
+	card := record{
+		fields: map[string][]string{
+			"status":   {"in_progress"},
+			"category": {"In Progress"},
+			"label":    {"bug", "backend"},
+			"assignee": {"daniils"},
+			"priority": {"2"},
+			"updated":  {"2026-03-14T10:00:00Z"},
+		},
+		text: "Fix login timeout",
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"status:in_progress", true},
+		{"status:IN_PROGRESS", true},
+		{"status:done", false},
+		{"label:bug", true},
+		{"label:feature,backend", true},
+		{"-label:bug", false},
+		{"label!=wontfix", true},
+		{"priority<=2", true},
+		{"priority<2", false},
+		{"priority>10", false},
+		{`category:"In Progress"`, true},
+		{"epic:none", true},
+		{"-epic:none", false},
+		{"updated>=2026-01-01", true},
+		{"updated<2026-01-01", false},
+		{"login", true},
+		{`"login timeout" -signup`, true},
+		{"status:done OR label:bug", true},
+		{"status:done OR (label:bug -assignee:daniils)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := Parse(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := query.Match(card); got != tt.want {
+				t.Errorf("expected %v got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	query, err := Parse("status:open label:bug (a OR b) -category:Done")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	taken, rest := query.Split(func(node Node) bool {
+		c, ok := node.(*Comparison)
+		return ok && c.Field == "status"
+	})
+
+	if len(taken) != 1 || Format(taken[0]) != "status:open" {
+		t.Errorf("unexpected taken terms: %v", taken)
+	}
+
+	if want := "label:bug (a OR b) -category:Done"; Format(rest) != want {
+		t.Errorf("expected rest %q got %q", want, Format(rest))
+	}
+}